@@ -13,6 +13,9 @@ func main() {
 	filename := flag.String("f", "config.json", "name of the config file in ~/.config/gysmo/")
 	useDataFile := flag.Bool("c", false, "use data file for all values")
 	showVersion := flag.Bool("v", false, "Show version of gysmo")
+	width := flag.Int("width", 0, "width of the terminal, detected when not set")
+	colorMode := flag.String("color", "auto", "when to use colors: auto, always or never")
+	themeName := flag.String("theme", "", "theme overriding the one of the config")
 
 	flag.Parse()
 
@@ -21,6 +24,12 @@ func main() {
 		return
 	}
 
+	colorLevel, err := src.DetectColorLevel(*colorMode)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
 	workingPath := src.LoadWorkingPath()
 	configPath := filepath.Join(workingPath, "config", *filename)
 	schemaPath := filepath.Join(workingPath, "config", "schema", "config_schema.json")
@@ -31,7 +40,7 @@ func main() {
 		return
 	}
 
-	err := src.ValidateJsonConfig(configPath, schemaPath)
+	err = src.ValidateJsonConfig(configPath, schemaPath)
 	if err != nil {
 		fmt.Println("Error validating config.json:", err)
 		return
@@ -49,10 +58,32 @@ func main() {
 		fmt.Println("Error loading config.json:", err)
 		return
 	}
+	// Every width is measured with the same policy, from the ASCII art to the menu
+	src.SetAmbiguousWidth(config.General.AmbiguousWidth)
+
+	if *themeName != "" {
+		config.Theme = *themeName
+	}
+	src.LoadPaletteSources()
+	theme, err := src.LoadTheme(config.Theme)
+	if err != nil {
+		fmt.Println("Error loading theme:", err)
+		return
+	}
+	src.SetTheme(theme)
+	if err := src.ValidateGradients(config); err != nil {
+		fmt.Println("Error loading config.json:", err)
+		return
+	}
+
+	// Breakpoints follow the terminal, or the --width flag standing for it, not the width of the menu
+	config = src.ApplyBreakpoints(config, src.TerminalWidth(*width, 0), src.TerminalHeight())
+	config.General.Width = src.TerminalWidth(*width, config.General.Width)
 
 	var asciiArt string
-	if config.Ascii.Enabled {
-		asciiArt, err = src.ReadAsciiArt(filepath.Join(asciiPath, config.Ascii.Path))
+	// The inline menu is a single line without ASCII art
+	if config.Ascii.Enabled && config.General.MenuType != "inline" {
+		asciiArt, config.Ascii.Colors, err = src.LoadAsciiArt(asciiPath, config.Ascii, src.LoadOsRelease())
 		if err != nil {
 			fmt.Println("Error reading ASCII art:", err)
 			return
@@ -65,8 +96,12 @@ func main() {
 		menu = src.BuildBoxMenu(src.MenuItems(config, *useDataFile), asciiArt, config)
 	case "list":
 		menu = src.BuildListMenu(src.MenuItems(config, *useDataFile), asciiArt, config)
+	case "grid":
+		menu = src.BuildGridMenu(src.MenuItems(config, *useDataFile), asciiArt, config)
+	case "inline":
+		menu = src.BuildInlineMenu(src.MenuItems(config, *useDataFile), config)
 	default:
 		menu = src.BuildBoxMenu(src.MenuItems(config, *useDataFile), asciiArt, config)
 	}
-	fmt.Println(menu)
+	fmt.Println(src.ApplyColorLevel(menu, colorLevel))
 }
@@ -2,11 +2,63 @@ package src
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+// The color placeholders of neofetch are ${c1} to ${c9}. Fastfetch logos also use $1 to $9, which are only
+// placeholders with ascii.placeholders "fastfetch" since they can be part of any art.
+var (
+	asciiPlaceholder     = regexp.MustCompile(`\$\{c([1-9])\}`)
+	fastfetchPlaceholder = regexp.MustCompile(`\$\{c([1-9])\}|\$([1-9])`)
+)
+
+// AsciiColors is the "colors" of the ASCII art. It is either a color or a list of colors, the first one
+// colors the whole art and the placeholders ${cN} switch to the Nth one.
+type AsciiColors []string
+
+func (colors *AsciiColors) UnmarshalJSON(data []byte) error {
+	var color string
+	if err := json.Unmarshal(data, &color); err == nil {
+		*colors = AsciiColors{color}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*colors = list
+	return nil
+}
+
+// Base is the color of the whole art.
+func (colors AsciiColors) Base() string {
+	if len(colors) == 0 {
+		return ""
+	}
+	return colors[0]
+}
+
+func (colors AsciiColors) isEmpty() bool {
+	for _, color := range colors {
+		if color != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (colors AsciiColors) code(n int) string {
+	if n > len(colors) {
+		return Reset
+	}
+	return GetColorCode(colors[n-1])
+}
+
 func ReadAsciiArt(filename string) (string, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -26,3 +78,35 @@ func ReadAsciiArt(filename string) (string, error) {
 
 	return asciiArt.String(), nil
 }
+
+// ColorAsciiArt replaces the color placeholders of the art with the escape sequences of their colors. A color
+// goes on until the next placeholder, so it is repeated at the start of the following lines, which are printed
+// one by one next to the menu. The placeholders take no room since widths ignore escape sequences.
+func ColorAsciiArt(asciiArt string, colors AsciiColors) string {
+	return colorPlaceholders(asciiArt, colors, asciiPlaceholder)
+}
+
+func colorPlaceholders(asciiArt string, colors AsciiColors, placeholder *regexp.Regexp) string {
+	if !placeholder.MatchString(asciiArt) {
+		return asciiArt
+	}
+	lines := strings.Split(asciiArt, "\n")
+	current := ""
+	for i, line := range lines {
+		prefix := current
+		lines[i] = prefix + placeholder.ReplaceAllStringFunc(line, func(match string) string {
+			groups := placeholder.FindStringSubmatch(match)
+			n, _ := strconv.Atoi(strings.Join(groups[1:], ""))
+			current = colors.code(n)
+			return current
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (ascii AsciiConfig) placeholder() *regexp.Regexp {
+	if ascii.Placeholders == "fastfetch" {
+		return fastfetchPlaceholder
+	}
+	return asciiPlaceholder
+}
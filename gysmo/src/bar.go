@@ -0,0 +1,74 @@
+package src
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	defaultBarWidth = 10
+	defaultBarFill  = "█"
+	defaultBarEmpty = "░"
+)
+
+// Partial blocks from 1/8 to 7/8, only used with the default fill character.
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// BarOptions holds the "bar" options of an item displayed as a progress bar.
+type BarOptions struct {
+	Width int    `json:"width"`
+	Fill  string `json:"fill"`
+	Empty string `json:"empty"`
+	Value string `json:"value"`
+}
+
+func (options BarOptions) withDefaults() BarOptions {
+	if options.Width <= 0 {
+		options.Width = defaultBarWidth
+	}
+	if options.Fill == "" {
+		options.Fill = defaultBarFill
+	}
+	if options.Empty == "" {
+		options.Empty = defaultBarEmpty
+	}
+	return options
+}
+
+// RenderBar draws a bar filled at percent, always options.Width cells wide. Fill and empty characters
+// wider than one cell are repeated fewer times, and spaces make up for a cell they can't fill.
+func RenderBar(percent float64, options BarOptions) string {
+	options = options.withDefaults()
+	percent = math.Max(0, math.Min(100, percent))
+	fillWidth := max(1, DisplayWidth(options.Fill))
+	emptyWidth := max(1, DisplayWidth(options.Empty))
+
+	cells := percent / 100 * float64(options.Width)
+	full := int(cells) / fillWidth
+	partial := ""
+	if options.Fill == defaultBarFill && fillWidth == 1 && full < options.Width {
+		partial = barEighths[int((cells-float64(full))*8)]
+	}
+
+	remaining := options.Width - full*fillWidth
+	if partial != "" {
+		remaining--
+	}
+	bar := strings.Repeat(options.Fill, full) + partial + strings.Repeat(options.Empty, remaining/emptyWidth)
+	return bar + strings.Repeat(" ", remaining%emptyWidth)
+}
+
+func barValue(value Value, item ConfigItem) string {
+	var options BarOptions
+	if item.Bar != nil {
+		options = *item.Bar
+	}
+	bar := RenderBar(value.Raw, options)
+	switch options.Value {
+	case "none":
+		return bar
+	case "left":
+		return value.Format(item.Format) + " " + bar
+	}
+	return bar + " " + value.Format(item.Format)
+}
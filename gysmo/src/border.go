@@ -0,0 +1,59 @@
+package src
+
+// BorderStyle is the set of characters drawing the box menu and the boxed grid.
+type BorderStyle struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	LeftT       string
+	RightT      string
+	TopT        string
+	BottomT     string
+	Cross       string
+}
+
+// Every character of a style has the same width, so boxes stay aligned with any ambiguous width policy.
+var borderStyles = map[string]BorderStyle{
+	"rounded": {"─", "│", "╭", "╮", "╰", "╯", "├", "┤", "┬", "┴", "┼"},
+	"sharp":   {"─", "│", "┌", "┐", "└", "┘", "├", "┤", "┬", "┴", "┼"},
+	"double":  {"═", "║", "╔", "╗", "╚", "╝", "╠", "╣", "╦", "╩", "╬"},
+	"heavy":   {"━", "┃", "┏", "┓", "┗", "┛", "┣", "┫", "┳", "┻", "╋"},
+	"dashed":  {"┄", "┆", "┌", "┐", "└", "┘", "├", "┤", "┬", "┴", "┼"},
+	"ascii":   {"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+"},
+	"none":    {" ", " ", " ", " ", " ", " ", " ", " ", " ", " ", " "},
+}
+
+// GetBorderStyle returns the general.border style, rounded by default.
+func GetBorderStyle(name string) BorderStyle {
+	if style, exists := borderStyles[name]; exists {
+		return style
+	}
+	return borderStyles["rounded"]
+}
+
+// roundToBorderWidth rounds width up to a whole number of horizontal border characters, which are two cells
+// wide with the "wide" ambiguous width policy.
+func roundToBorderWidth(width int, style BorderStyle) int {
+	cell := DisplayWidth(style.Horizontal)
+	if cell <= 1 || width%cell == 0 {
+		return width
+	}
+	return width + cell - width%cell
+}
+
+func paintBorder(config Config, s string) string {
+	if config.General.BorderColor == "" {
+		return s
+	}
+	return GetColorCode(config.General.BorderColor) + s + Reset
+}
+
+func lineColorCode(config Config, lineColor string) string {
+	if lineColor == "" && config.General.BorderColor != "" {
+		return GetColorCode(config.General.BorderColor)
+	}
+	return GetColorCode(lineColor)
+}
@@ -0,0 +1,78 @@
+package src
+
+import "slices"
+
+// BreakpointAscii overrides the ascii section when a breakpoint matches.
+type BreakpointAscii struct {
+	Position string `json:"position"`
+	Size     string `json:"size"`
+	Enabled  *bool  `json:"enabled"`
+}
+
+// Breakpoint changes the layout for some terminal sizes. Bounds are inclusive, 0 means no bound.
+type Breakpoint struct {
+	MinWidth  int              `json:"min_width"`
+	MaxWidth  int              `json:"max_width"`
+	MinHeight int              `json:"min_height"`
+	MaxHeight int              `json:"max_height"`
+	Ascii     *BreakpointAscii `json:"ascii"`
+	MenuType  string           `json:"menu_type"`
+	Columns   *bool            `json:"columns"`
+	Hide      []string         `json:"hide"`
+}
+
+// Matches reports whether the terminal size is within the bounds. Bounds on an unknown size (0) never match.
+func (breakpoint Breakpoint) Matches(width int, height int) bool {
+	return withinBounds(width, breakpoint.MinWidth, breakpoint.MaxWidth) &&
+		withinBounds(height, breakpoint.MinHeight, breakpoint.MaxHeight)
+}
+
+func withinBounds(size int, minimum int, maximum int) bool {
+	if minimum == 0 && maximum == 0 {
+		return true
+	}
+	if size <= 0 {
+		return false
+	}
+	return size >= minimum && (maximum == 0 || size <= maximum)
+}
+
+// ApplyBreakpoints applies every matching breakpoint in order, so later ones win.
+func ApplyBreakpoints(config Config, width int, height int) Config {
+	for _, breakpoint := range config.General.Breakpoints {
+		if !breakpoint.Matches(width, height) {
+			continue
+		}
+		if breakpoint.Ascii != nil {
+			if breakpoint.Ascii.Position != "" {
+				config.Ascii.Position = breakpoint.Ascii.Position
+			}
+			if breakpoint.Ascii.Size != "" {
+				config.Ascii.Size = breakpoint.Ascii.Size
+			}
+			if breakpoint.Ascii.Enabled != nil {
+				config.Ascii.Enabled = *breakpoint.Ascii.Enabled
+			}
+		}
+		if breakpoint.MenuType != "" {
+			config.General.MenuType = breakpoint.MenuType
+		}
+		if breakpoint.Columns != nil {
+			config.General.Columns = *breakpoint.Columns
+		}
+		if len(breakpoint.Hide) > 0 {
+			config.Items = hideItems(config.Items, breakpoint.Hide)
+		}
+	}
+	return config
+}
+
+func hideItems(configItems []ConfigItem, hidden []string) []ConfigItem {
+	visible := []ConfigItem{}
+	for _, item := range configItems {
+		if !slices.Contains(hidden, item.Key()) && !slices.Contains(hidden, item.Text) {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
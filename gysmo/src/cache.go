@@ -0,0 +1,81 @@
+package src
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a value stored in data/cache.json along with the time it was fetched.
+type CacheEntry struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ETag      string    `json:"etag,omitempty"`
+}
+
+var cacheMu sync.Mutex
+
+// Fresh reports whether the entry is younger than ttl.
+func (entry CacheEntry) Fresh(ttl time.Duration) bool {
+	return ttl > 0 && time.Since(entry.UpdatedAt) < ttl
+}
+
+func cachePath() string {
+	return filepath.Join(LoadWorkingPath(), "data", "cache.json")
+}
+
+func readCacheFile() map[string]CacheEntry {
+	entries := make(map[string]CacheEntry)
+	data, err := ReadFile(cachePath())
+	if err != nil {
+		return entries
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return make(map[string]CacheEntry)
+	}
+	return entries
+}
+
+// ReadCache returns the cached entry for key, whatever its age.
+func ReadCache(key string) (CacheEntry, bool) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entry, exists := readCacheFile()[key]
+	return entry, exists
+}
+
+// LoadCache returns the cached value for key if it is younger than ttl.
+func LoadCache(key string, ttl time.Duration) (string, bool) {
+	entry, exists := ReadCache(key)
+	if !exists || !entry.Fresh(ttl) {
+		return "", false
+	}
+	return entry.Value, true
+}
+
+// SaveCache stores value under key with the current time.
+func SaveCache(key string, value string) error {
+	return SaveCacheEntry(key, CacheEntry{Value: value, UpdatedAt: time.Now()})
+}
+
+func SaveCacheEntry(key string, entry CacheEntry) error {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entries := readCacheFile()
+	entries[key] = entry
+
+	path := cachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,65 @@
+package src
+
+import (
+	"encoding/json"
+)
+
+// ColorRule picks a color when the numeric value is above and/or below a threshold.
+// A rule without threshold always matches, it is usually the last one.
+type ColorRule struct {
+	Above *float64 `json:"above"`
+	Below *float64 `json:"below"`
+	Color string   `json:"color"`
+}
+
+// ColorRules is the "value_color" of an item. It is either a color or a list of rules,
+// the first matching rule wins.
+type ColorRules []ColorRule
+
+func (rules *ColorRules) UnmarshalJSON(data []byte) error {
+	var color string
+	if err := json.Unmarshal(data, &color); err == nil {
+		*rules = ColorRules{{Color: color}}
+		return nil
+	}
+	var list []ColorRule
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*rules = list
+	return nil
+}
+
+func (rule ColorRule) matches(value Value) bool {
+	if rule.Above == nil && rule.Below == nil {
+		return true
+	}
+	if !value.Numeric {
+		return false
+	}
+	if rule.Above != nil && value.Raw <= *rule.Above {
+		return false
+	}
+	if rule.Below != nil && value.Raw >= *rule.Below {
+		return false
+	}
+	return true
+}
+
+// Color returns the color of the first rule matching the value, or "" when none match.
+func (rules ColorRules) Color(value Value) string {
+	for _, rule := range rules {
+		if rule.matches(value) {
+			return rule.Color
+		}
+	}
+	return ""
+}
+
+func valueColorCode(item ConfigItem, items map[string]Value) string {
+	value, exists := items[item.Key()]
+	if !exists {
+		value = ParseValue(item.Value)
+	}
+	return GetColorCode(item.ValueColor.Color(value))
+}
@@ -0,0 +1,178 @@
+package src
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ColorLevel is the number of colors the output can use.
+type ColorLevel int
+
+const (
+	NoColor ColorLevel = iota
+	Color16
+	Color256
+	TrueColor
+)
+
+// The xterm values of the 16 ANSI colors, used to find the closest one to an RGB color.
+var ansiPalette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+var sgrPattern = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// DetectColorLevel picks the colors of the output from the --color flag: never, always or auto.
+// auto turns colors off when NO_COLOR is set or stdout is not a terminal, unless CLICOLOR_FORCE is set.
+func DetectColorLevel(mode string) (ColorLevel, error) {
+	switch mode {
+	case "never":
+		return NoColor, nil
+	case "always":
+		return terminalColorLevel(), nil
+	case "", "auto":
+	default:
+		return NoColor, fmt.Errorf("unknown color mode %q, expected auto, always or never", mode)
+	}
+
+	if value, exists := LookupEnv("NO_COLOR"); exists && value != "" {
+		return NoColor, nil
+	}
+	if value, exists := LookupEnv("CLICOLOR_FORCE"); exists && value != "" && value != "0" {
+		return terminalColorLevel(), nil
+	}
+	if !StdoutIsTerminal() {
+		return NoColor, nil
+	}
+	if term, _ := LookupEnv("TERM"); term == "dumb" {
+		return NoColor, nil
+	}
+	return terminalColorLevel(), nil
+}
+
+func terminalColorLevel() ColorLevel {
+	colorTerm, _ := LookupEnv("COLORTERM")
+	term, _ := LookupEnv("TERM")
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit" || strings.HasSuffix(term, "-direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return Color256
+	}
+	return Color16
+}
+
+// ApplyColorLevel rewrites the escape codes of the output for the level: they are removed without colors,
+// and RGB and 256-color codes are turned into the closest color the terminal supports.
+func ApplyColorLevel(s string, level ColorLevel) string {
+	switch level {
+	case NoColor:
+		return sgrPattern.ReplaceAllString(s, "")
+	case TrueColor:
+		return s
+	}
+	return sgrPattern.ReplaceAllStringFunc(s, func(code string) string {
+		parameters := strings.Split(sgrPattern.FindStringSubmatch(code)[1], ";")
+		return "\033[" + strings.Join(downsample(parameters, level), ";") + "m"
+	})
+}
+
+func downsample(parameters []string, level ColorLevel) []string {
+	result := []string{}
+	for i := 0; i < len(parameters); i++ {
+		parameter := parameters[i]
+		if (parameter != "38" && parameter != "48") || i+1 >= len(parameters) {
+			result = append(result, parameter)
+			continue
+		}
+		background := parameter == "48"
+		values := parameters[i+2:]
+		switch {
+		case parameters[i+1] == "2" && len(values) >= 3:
+			r, _ := strconv.Atoi(values[0])
+			g, _ := strconv.Atoi(values[1])
+			b, _ := strconv.Atoi(values[2])
+			if level == Color256 {
+				result = append(result, parameter, "5", strconv.Itoa(rgbTo256(r, g, b)))
+			} else {
+				result = append(result, ansiParameter(nearestAnsi(r, g, b), background))
+			}
+			i += 4
+		case parameters[i+1] == "5" && len(values) >= 1:
+			index, _ := strconv.Atoi(values[0])
+			if level == Color256 {
+				result = append(result, parameter, "5", values[0])
+			} else {
+				result = append(result, ansiParameter(ansi256To16(index), background))
+			}
+			i += 2
+		default:
+			result = append(result, parameter)
+		}
+	}
+	return result
+}
+
+func rgbTo256(r, g, b int) int {
+	cube := func(v int) int {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	index := 16 + 36*cube(r) + 6*cube(g) + cube(b)
+	cubeDistance := colorDistance([3]int{r, g, b}, [3]int{cubeLevels[cube(r)], cubeLevels[cube(g)], cubeLevels[cube(b)]})
+
+	gray := min(23, max(0, ((r+g+b)/3-3)/10))
+	grayLevel := 8 + 10*gray
+	if colorDistance([3]int{r, g, b}, [3]int{grayLevel, grayLevel, grayLevel}) < cubeDistance {
+		return 232 + gray
+	}
+	return index
+}
+
+func ansi256To16(index int) int {
+	switch {
+	case index < 16:
+		return max(0, index)
+	case index >= 232:
+		level := 8 + 10*(min(255, index)-232)
+		return nearestAnsi(level, level, level)
+	}
+	index -= 16
+	return nearestAnsi(cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6])
+}
+
+func nearestAnsi(r, g, b int) int {
+	nearest := 0
+	for i, color := range ansiPalette {
+		if colorDistance([3]int{r, g, b}, color) < colorDistance([3]int{r, g, b}, ansiPalette[nearest]) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+func colorDistance(a, b [3]int) int {
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dr*dr + dg*dg + db*db
+}
+
+func ansiParameter(index int, background bool) string {
+	parameter := 30 + index
+	if index >= 8 {
+		parameter = 90 + index - 8
+	}
+	if background {
+		parameter += 10
+	}
+	return strconv.Itoa(parameter)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -14,45 +16,163 @@ var (
 	}
 )
 
+type HTTPSource struct {
+	URL      string            `json:"url"`
+	Pointer  string            `json:"pointer"`
+	Headers  map[string]string `json:"headers"`
+	Timeout  int               `json:"timeout"`
+	CacheTTL int               `json:"cache_ttl"`
+}
+
 type ConfigItem struct {
-	Text       string `json:"text"`
-	Keyword    string `json:"keyword"`
-	Icon       string `json:"icon"`
-	TextColor  string `json:"text_color"`
-	ValueColor string `json:"value_color"`
-	IconColor  string `json:"icon_color"`
-	Value      string `json:"value"`
+	Text       string            `json:"text"`
+	Keyword    string            `json:"keyword"`
+	Icon       string            `json:"icon"`
+	TextColor  string            `json:"text_color"`
+	ValueColor ColorRules        `json:"value_color"`
+	IconColor  string            `json:"icon_color"`
+	Value      string            `json:"value"`
+	HTTP       *HTTPSource       `json:"http"`
+	File       string            `json:"file"`
+	Repo       string            `json:"repo"`
+	User       string            `json:"user"`
+	Regex      string            `json:"regex"`
+	Line       int               `json:"line"`
+	Trim       bool              `json:"trim"`
+	Format     *ValueFormat      `json:"format"`
+	Display    string            `json:"display"`
+	Bar        *BarOptions       `json:"bar"`
+	Sparkline  *SparklineOptions `json:"sparkline"`
+	Overflow   string            `json:"overflow"`
+	Group      *ItemGroup        `json:"-"`
+}
+
+// Key is the name under which the value of the item is stored in the data file.
+func (item ConfigItem) Key() string {
+	if item.HTTP != nil {
+		return "http " + item.HTTP.URL + "#" + item.HTTP.Pointer + item.HTTP.headersKey()
+	}
+	if item.File != "" {
+		key := "file " + item.File
+		if item.Line != 0 {
+			key += ":" + strconv.Itoa(item.Line)
+		}
+		if item.Regex != "" {
+			key += " /" + item.Regex + "/"
+		}
+		return key
+	}
+	if item.HasGitHubTarget() {
+		key := item.Keyword
+		if item.Repo != "" {
+			key += " " + item.Repo
+		}
+		if item.User != "" {
+			key += " @" + item.User
+		}
+		return key
+	}
+	return item.Keyword
+}
+
+// HasGitHubTarget tells if a github keyword item sets its own repo or user instead of the github section.
+func (item ConfigItem) HasGitHubTarget() bool {
+	return strings.HasPrefix(item.Keyword, "github_") && (item.Repo != "" || item.User != "")
+}
+
+type PublicIPProvider struct {
+	URL    string `json:"url"`
+	Format string `json:"format"`
+	Field  string `json:"field"`
+}
+
+type PublicIPConfig struct {
+	Providers []PublicIPProvider `json:"providers"`
+	CacheTTL  int                `json:"cache_ttl"`
+}
+
+type WeatherConfig struct {
+	Provider     string  `json:"provider"`
+	Location     string  `json:"location"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	Units        string  `json:"units"`
+	ForecastDays int     `json:"forecast_days"`
+	URL          string  `json:"url"`
+	CacheTTL     int     `json:"cache_ttl"`
+}
+
+type GitHubConfig struct {
+	Repo      string `json:"repo"`
+	User      string `json:"user"`
+	TokenEnv  string `json:"token_env"`
+	TokenFile string `json:"token_file"`
+	APIURL    string `json:"api_url"`
+	CacheTTL  int    `json:"cache_ttl"`
+}
+
+type HistoryConfig struct {
+	Samples int `json:"samples"`
+}
+
+type AsciiConfig struct {
+	Path              string      `json:"path"`
+	Colors            AsciiColors `json:"colors"`
+	Enabled           bool        `json:"enabled"`
+	HorizontalPadding int         `json:"horizontal_padding"`
+	VerticalPadding   int         `json:"vertical_padding"`
+	Position          string      `json:"position"`
+	Size              string      `json:"size"`
+	Gradient          Gradient    `json:"gradient"`
+	Placeholders      string      `json:"placeholders"`
+}
+
+type HeaderConfig struct {
+	Text      string   `json:"text"`
+	TextColor string   `json:"text_color"`
+	LineColor string   `json:"line_color"`
+	Line      bool     `json:"line"`
+	Enabled   bool     `json:"enabled"`
+	Align     string   `json:"align"`
+	InBorder  bool     `json:"in_border"`
+	Gradient  Gradient `json:"gradient"`
+}
+
+type FooterConfig struct {
+	Text      string   `json:"text"`
+	TextColor string   `json:"text_color"`
+	LineColor string   `json:"line_color"`
+	Line      bool     `json:"line"`
+	Enabled   bool     `json:"enabled"`
+	Align     string   `json:"align"`
+	InBorder  bool     `json:"in_border"`
+	Gradient  Gradient `json:"gradient"`
+}
+
+type GeneralConfig struct {
+	MenuType       string       `json:"menu_type"`
+	Columns        bool         `json:"columns"`
+	MenuPadding    int          `json:"menu_padding"`
+	AmbiguousWidth string       `json:"ambiguous_width"`
+	Width          int          `json:"width"`
+	Border         string       `json:"border"`
+	BorderColor    string       `json:"border_color"`
+	Breakpoints    []Breakpoint `json:"breakpoints"`
+	Grid           GridConfig   `json:"grid"`
+	Inline         InlineConfig `json:"inline"`
 }
 
 type Config struct {
-	Items []ConfigItem `json:"items"`
-	Ascii struct {
-		Path              string `json:"path"`
-		Colors            string `json:"colors"`
-		Enabled           bool   `json:"enabled"`
-		HorizontalPadding int    `json:"horizontal_padding"`
-		VerticalPadding   int    `json:"vertical_padding"`
-		Position          string `json:"position"`
-	} `json:"ascii"`
-	Header struct {
-		Text      string `json:"text"`
-		TextColor string `json:"text_color"`
-		LineColor string `json:"line_color"`
-		Line      bool   `json:"line"`
-		Enabled   bool   `json:"enabled"`
-	} `json:"header"`
-	Footer struct {
-		Text      string `json:"text"`
-		TextColor string `json:"text_color"`
-		LineColor string `json:"line_color"`
-		Line      bool   `json:"line"`
-		Enabled   bool   `json:"enabled"`
-	} `json:"footer"`
-	General struct {
-		MenuType    string `json:"menu_type"`
-		Columns     bool   `json:"columns"`
-		MenuPadding int    `json:"menu_padding"`
-	} `json:"general"`
+	Items    ConfigItems    `json:"items"`
+	Ascii    AsciiConfig    `json:"ascii"`
+	Header   HeaderConfig   `json:"header"`
+	Footer   FooterConfig   `json:"footer"`
+	General  GeneralConfig  `json:"general"`
+	PublicIP PublicIPConfig `json:"public_ip"`
+	Weather  WeatherConfig  `json:"weather"`
+	GitHub   GitHubConfig   `json:"github"`
+	History  HistoryConfig  `json:"history"`
+	Theme    string         `json:"theme"`
 }
 
 func LoadConfig(filename string) (Config, error) {
@@ -70,6 +190,14 @@ func LoadConfig(filename string) (Config, error) {
 		return config, fmt.Errorf("error decoding config file: %w", err)
 	}
 
+	if err := ValidateTemplates(config); err != nil {
+		return config, err
+	}
+
+	if err := ValidateStyles(config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
 
@@ -86,10 +214,17 @@ func ValidateJsonConfig(configPath, schemaPath string) error {
 		var errorMessages string
 		for _, desc := range result.Errors() {
 			switch desc.Type() {
+			case "condition_then", "condition_else":
+				// Items and groups are told apart with if/then/else, the errors inside them are reported
+				continue
 			case "required":
 				errorMessages += fmt.Sprintf("Missing required field: %s\n", desc.Field())
 			case "number_one_of":
-				errorMessages += fmt.Sprintf("Field %s You need to specify either Keyword or Value for an item.\n", desc.Field())
+				if strings.HasSuffix(desc.Field(), ".value_color") {
+					errorMessages += fmt.Sprintf("Field %s must be a color or a list of color rules.\n", desc.Field())
+					continue
+				}
+				errorMessages += fmt.Sprintf("Field %s You need to specify exactly one of Keyword, Value, Http or File for an item.\n", desc.Field())
 			default:
 				errorMessages += fmt.Sprintf("Validation error on field %s: %s\n", desc.Field(), desc.Description())
 			}
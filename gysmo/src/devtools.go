@@ -12,6 +12,9 @@ import (
 // Function type for functions that return a string
 type StringFunc func() string
 
+// Function type for functions that return a typed Value
+type ValueFunc func() Value
+
 // Function type for functions that return two uint64 values
 type Uint64Func func() (uint64, uint64)
 
@@ -23,6 +26,14 @@ func MeasureTime(name string, f StringFunc) (string, time.Duration) {
 	return result, duration
 }
 
+// MeasureTimeValue is a higher-order function that measures the execution time of a function returning a Value
+func MeasureTimeValue(name string, f ValueFunc) (Value, time.Duration) {
+	start := time.Now()
+	result := f()
+	duration := time.Since(start)
+	return result, duration
+}
+
 // MeasureTimeUint64 is a higher-order function that measures the execution time of a function returning two uint64 values
 func MeasureTimeUint64(name string, f Uint64Func) (uint64, uint64, time.Duration) {
 	start := time.Now()
@@ -69,67 +80,67 @@ func MeasureAllFunctions() {
 	var results []FunctionResult
 
 	// Measure each function and store the results
-	_, duration := MeasureTime("GetCPUInfo", GetCPUInfo)
+	_, duration := MeasureTimeValue("GetCPUInfo", GetCPUInfo)
 	results = append(results, FunctionResult{"GetCPUInfo", duration})
 
-	_, duration = MeasureTime("GetRAMUsage", GetRAMUsage)
+	_, duration = MeasureTimeValue("GetRAMUsage", GetRAMUsage)
 	results = append(results, FunctionResult{"GetRAMUsage", duration})
 
-	_, duration = MeasureTime("GetRAMInfo", GetRAMInfo)
+	_, duration = MeasureTimeValue("GetRAMInfo", GetRAMInfo)
 	results = append(results, FunctionResult{"GetRAMInfo", duration})
 
 	_, _, duration = MeasureTimeUint64("GetCPUSample", GetCPUSample)
 	results = append(results, FunctionResult{"GetCPUSample", duration})
 
-	_, duration = MeasureTime("GetShell", GetShell)
+	_, duration = MeasureTimeValue("GetShell", GetShell)
 	results = append(results, FunctionResult{"GetShell", duration})
 
-	_, duration = MeasureTime("GetTerminal", GetTerminal)
+	_, duration = MeasureTimeValue("GetTerminal", GetTerminal)
 	results = append(results, FunctionResult{"GetTerminal", duration})
 
-	_, duration = MeasureTime("GetDriveInfo", GetDriveInfo)
+	_, duration = MeasureTimeValue("GetDriveInfo", GetDriveInfo)
 	results = append(results, FunctionResult{"GetDriveInfo", duration})
 
-	_, duration = MeasureTime("GetDriveUsage", GetDriveUsage)
+	_, duration = MeasureTimeValue("GetDriveUsage", GetDriveUsage)
 	results = append(results, FunctionResult{"GetDriveUsage", duration})
 
-	_, duration = MeasureTime("GetGPUInfo", GetGPUInfo)
+	_, duration = MeasureTimeValue("GetGPUInfo", GetGPUInfo)
 	results = append(results, FunctionResult{"GetGPUInfo", duration})
 
-	_, duration = MeasureTime("GetGPUUsage", GetGPUUsage)
+	_, duration = MeasureTimeValue("GetGPUUsage", GetGPUUsage)
 	results = append(results, FunctionResult{"GetGPUUsage", duration})
 
-	_, duration = MeasureTime("GetNvidiaGPUInfo", GetNvidiaGPUInfo)
+	_, duration = MeasureTimeValue("GetNvidiaGPUInfo", GetNvidiaGPUInfo)
 	results = append(results, FunctionResult{"GetNvidiaGPUInfo", duration})
 
-	_, duration = MeasureTime("GetNvidiaGPUUsage", GetNvidiaGPUUsage)
+	_, duration = MeasureTimeValue("GetNvidiaGPUUsage", GetNvidiaGPUUsage)
 	results = append(results, FunctionResult{"GetNvidiaGPUUsage", duration})
 
-	_, duration = MeasureTime("GetAmdGPUInfo", GetAmdGPUInfo)
+	_, duration = MeasureTimeValue("GetAmdGPUInfo", GetAmdGPUInfo)
 	results = append(results, FunctionResult{"GetAmdGPUInfo", duration})
 
-	_, duration = MeasureTime("GetAmdGPUUsage", GetAmdGPUUsage)
+	_, duration = MeasureTimeValue("GetAmdGPUUsage", GetAmdGPUUsage)
 	results = append(results, FunctionResult{"GetAmdGPUUsage", duration})
 
-	_, duration = MeasureTime("GetIntelGPUInfo", GetIntelGPUInfo)
+	_, duration = MeasureTimeValue("GetIntelGPUInfo", GetIntelGPUInfo)
 	results = append(results, FunctionResult{"GetIntelGPUInfo", duration})
 
-	_, duration = MeasureTime("GetIntelGPUUsage", GetIntelGPUUsage)
+	_, duration = MeasureTimeValue("GetIntelGPUUsage", GetIntelGPUUsage)
 	results = append(results, FunctionResult{"GetIntelGPUUsage", duration})
 
-	_, duration = MeasureTime("GetUptime", GetUptime)
+	_, duration = MeasureTimeValue("GetUptime", GetUptime)
 	results = append(results, FunctionResult{"GetUptime", duration})
 
-	_, duration = MeasureTime("GetWM", GetWM)
+	_, duration = MeasureTimeValue("GetWM", GetWM)
 	results = append(results, FunctionResult{"GetWM", duration})
 
-	_, duration = MeasureTime("GetResolution", GetResolution)
+	_, duration = MeasureTimeValue("GetResolution", GetResolution)
 	results = append(results, FunctionResult{"GetResolution", duration})
 
-	_, duration = MeasureTime("GetIP", GetIP)
+	_, duration = MeasureTimeValue("GetIP", GetIP)
 	results = append(results, FunctionResult{"GetIP", duration})
 
-	_, duration = MeasureTime("GetPublicIP", GetPublicIP)
+	_, duration = MeasureTimeValue("GetPublicIP", func() Value { return GetPublicIP(PublicIPConfig{}) })
 	results = append(results, FunctionResult{"GetPublicIP", duration})
 
 	// measure menuitems function
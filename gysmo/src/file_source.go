@@ -0,0 +1,68 @@
+package src
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GetFileValue reads the file of the item without spawning a shell. The path can be a glob,
+// in which case the first match is used, then the line and regex options narrow the content down.
+func GetFileValue(item ConfigItem) Value {
+	text, err := readFileValue(item)
+	if err != nil {
+		return saveSourceValue(item.Key(), ErrorValue(err))
+	}
+	return saveSourceValue(item.Key(), ParseValue(text))
+}
+
+func readFileValue(item ConfigItem) (string, error) {
+	path := ExpandHome(item.File)
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) > 0 {
+		path = matches[0]
+	}
+
+	data, err := ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	// Lines start at 1, negative values count from the end of the file
+	if item.Line != 0 {
+		lines := strings.Split(content, "\n")
+		index := item.Line - 1
+		if item.Line < 0 {
+			index = len(lines) + item.Line
+		}
+		if index < 0 || index >= len(lines) {
+			return "", fmt.Errorf("%s has no line %d", path, item.Line)
+		}
+		content = lines[index]
+	}
+
+	if item.Regex != "" {
+		re, err := regexp.Compile(item.Regex)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindStringSubmatch(content)
+		if match == nil {
+			return "", fmt.Errorf("%s does not match %s", path, item.Regex)
+		}
+		content = match[0]
+		if len(match) > 1 {
+			content = match[1]
+		}
+	}
+
+	if item.Trim {
+		content = strings.TrimSpace(content)
+	}
+	return content, nil
+}
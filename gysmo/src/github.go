@@ -0,0 +1,302 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultGitHubAPIURL   = "https://api.github.com"
+	defaultGitHubTokenEnv = "GITHUB_TOKEN"
+	defaultGitHubCacheTTL = 10 * time.Minute
+	gitHubRateLimitKey    = "github rate limit reset"
+	gitHubSearchResource  = "search"
+	gitHubCoreResource    = "core"
+)
+
+var githubMu sync.Mutex
+
+type gitHubRepo struct {
+	Stars      int `json:"stargazers_count"`
+	Forks      int `json:"forks_count"`
+	OpenIssues int `json:"open_issues_count"`
+}
+
+type gitHubSearch struct {
+	TotalCount int `json:"total_count"`
+}
+
+type gitHubUser struct {
+	Followers int `json:"followers"`
+}
+
+var gitHubKeywords = map[string]func(GitHubConfig) Value{
+	"github_stars":     gitHubStars,
+	"github_forks":     gitHubForks,
+	"github_issues":    gitHubIssues,
+	"github_prs":       gitHubPRs,
+	"github_followers": gitHubFollowers,
+}
+
+func GetGitHubStars(config GitHubConfig) Value {
+	return saveGitHubValue("github_stars", gitHubStars(config))
+}
+
+func GetGitHubForks(config GitHubConfig) Value {
+	return saveGitHubValue("github_forks", gitHubForks(config))
+}
+
+func GetGitHubIssues(config GitHubConfig) Value {
+	return saveGitHubValue("github_issues", gitHubIssues(config))
+}
+
+func GetGitHubPRs(config GitHubConfig) Value {
+	return saveGitHubValue("github_prs", gitHubPRs(config))
+}
+
+func GetGitHubFollowers(config GitHubConfig) Value {
+	return saveGitHubValue("github_followers", gitHubFollowers(config))
+}
+
+// GetGitHubItemValue returns the github keyword of an item with its own repo or user, the github section
+// giving the ones it does not set.
+func GetGitHubItemValue(item ConfigItem, config GitHubConfig) Value {
+	if item.Repo != "" {
+		config.Repo = item.Repo
+	}
+	if item.User != "" {
+		config.User = item.User
+	}
+	fetch, exists := gitHubKeywords[item.Keyword]
+	if !exists {
+		return saveGitHubValue(item.Key(), ErrorValue(fmt.Errorf("%q is not a github keyword", item.Keyword)))
+	}
+	return saveGitHubValue(item.Key(), fetch(config))
+}
+
+func gitHubStars(config GitHubConfig) Value {
+	return gitHubRepoValue(config, func(repo gitHubRepo) int { return repo.Stars })
+}
+
+func gitHubForks(config GitHubConfig) Value {
+	return gitHubRepoValue(config, func(repo gitHubRepo) int { return repo.Forks })
+}
+
+// gitHubIssues returns open issues without pull requests, which GitHub counts as issues too.
+func gitHubIssues(config GitHubConfig) Value {
+	var repo gitHubRepo
+	var prs gitHubSearch
+	if err := fetchGitHub(config, "/repos/"+config.Repo, &repo); err != nil {
+		return ErrorValue(err)
+	}
+	if err := fetchGitHub(config, gitHubPRSearchPath(config), &prs); err != nil {
+		return ErrorValue(err)
+	}
+	return CountValue(max(0, repo.OpenIssues-prs.TotalCount))
+}
+
+func gitHubPRs(config GitHubConfig) Value {
+	var prs gitHubSearch
+	if err := fetchGitHub(config, gitHubPRSearchPath(config), &prs); err != nil {
+		return ErrorValue(err)
+	}
+	return CountValue(prs.TotalCount)
+}
+
+func gitHubFollowers(config GitHubConfig) Value {
+	user := config.User
+	if user == "" {
+		user = strings.Split(config.Repo, "/")[0]
+	}
+	if user == "" {
+		return ErrorValue(fmt.Errorf("github user is not configured"))
+	}
+
+	var profile gitHubUser
+	if err := fetchGitHub(config, "/users/"+user, &profile); err != nil {
+		return ErrorValue(err)
+	}
+	return CountValue(profile.Followers)
+}
+
+func gitHubRepoValue(config GitHubConfig, field func(gitHubRepo) int) Value {
+	var repo gitHubRepo
+	if err := fetchGitHub(config, "/repos/"+config.Repo, &repo); err != nil {
+		return ErrorValue(err)
+	}
+	return CountValue(field(repo))
+}
+
+func saveGitHubValue(keyword string, value Value) Value {
+	SaveValuesToFile(map[string]Value{keyword: value})
+	return value
+}
+
+func gitHubPRSearchPath(config GitHubConfig) string {
+	return "/search/issues?per_page=1&q=" + url.QueryEscape("repo:"+config.Repo+" is:pr is:open")
+}
+
+// GitHubToken reads the token from the configured env var first, then from the token file.
+func GitHubToken(config GitHubConfig) string {
+	envVar := config.TokenEnv
+	if envVar == "" {
+		envVar = defaultGitHubTokenEnv
+	}
+	if token, exists := LookupEnv(envVar); exists && token != "" {
+		return token
+	}
+
+	if config.TokenFile == "" {
+		return ""
+	}
+	data, err := ReadFile(ExpandHome(config.TokenFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// fetchGitHub decodes an API response into target. Responses are cached with their ETag so
+// revalidating them does not use the rate limit, and no request is sent while the limit is exhausted.
+func fetchGitHub(config GitHubConfig, path string, target any) error {
+	githubMu.Lock()
+	defer githubMu.Unlock()
+
+	if config.Repo == "" && !strings.HasPrefix(path, "/users/") {
+		return fmt.Errorf("github repo is not configured")
+	}
+
+	ttl := defaultGitHubCacheTTL
+	if config.CacheTTL != 0 {
+		ttl = time.Duration(config.CacheTTL) * time.Second
+	}
+
+	apiURL := strings.TrimRight(config.APIURL, "/")
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	cacheKey := "github " + apiURL + path
+	cached, hasCache := ReadCache(cacheKey)
+	if hasCache && cached.Fresh(ttl) {
+		return json.Unmarshal([]byte(cached.Value), target)
+	}
+
+	if gitHubRateLimited(apiURL, gitHubResource(path)) {
+		if hasCache {
+			return json.Unmarshal([]byte(cached.Value), target)
+		}
+		return fmt.Errorf("github rate limit exceeded")
+	}
+
+	body, etag, err := requestGitHub(config, apiURL, path, cached.ETag)
+	if err != nil {
+		if hasCache {
+			return json.Unmarshal([]byte(cached.Value), target)
+		}
+		return err
+	}
+
+	if body == nil {
+		// 304 Not Modified, the cached body is still current
+		body = []byte(cached.Value)
+		etag = cached.ETag
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		SaveCacheEntry(cacheKey, CacheEntry{Value: string(body), UpdatedAt: time.Now(), ETag: etag})
+	}
+	return nil
+}
+
+// requestGitHub returns a nil body when the server answers 304 Not Modified.
+func requestGitHub(config GitHubConfig, apiURL string, path string, etag string) ([]byte, string, error) {
+	endpoint := apiURL + path
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := GitHubToken(config); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := HttpDo(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = gitHubResource(path)
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		SaveCache(gitHubRateLimitCacheKey(apiURL, resource), resp.Header.Get("X-RateLimit-Reset"))
+	}
+	// The secondary rate limits answer 403 or 429 with the seconds to wait
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if reset, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			SaveCache(gitHubRateLimitCacheKey(apiURL, resource), strconv.FormatInt(reset.Unix(), 10))
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, etag, nil
+	case http.StatusOK:
+		body, err := ReadResponse(resp.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		return body, resp.Header.Get("ETag"), nil
+	}
+	return nil, "", fmt.Errorf("unexpected status from %s: %s", endpoint, resp.Status)
+}
+
+func gitHubResource(path string) string {
+	if strings.HasPrefix(path, "/search/") {
+		return gitHubSearchResource
+	}
+	return gitHubCoreResource
+}
+
+func gitHubRateLimitCacheKey(apiURL string, resource string) string {
+	return gitHubRateLimitKey + " " + apiURL + " " + resource
+}
+
+func retryAfter(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+func gitHubRateLimited(apiURL string, resource string) bool {
+	entry, exists := ReadCache(gitHubRateLimitCacheKey(apiURL, resource))
+	if !exists {
+		return false
+	}
+	reset, err := strconv.ParseInt(entry.Value, 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.Now().Before(time.Unix(reset, 0))
+}
@@ -0,0 +1,128 @@
+package src
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Gradient colors the ASCII art, the header or the footer character by character, going through two or
+// more RGB stops horizontally (default), vertically or diagonally. Terminals without RGB colors
+// get the closest colors they support, see ApplyColorLevel.
+type Gradient struct {
+	Stops     []string `json:"stops"`
+	Direction string   `json:"direction"`
+}
+
+func (gradient Gradient) enabled() bool {
+	return len(gradient.Stops) >= 2
+}
+
+func (gradient Gradient) validate() error {
+	if len(gradient.Stops) == 1 {
+		return fmt.Errorf("a gradient needs at least two stops")
+	}
+	_, err := gradient.rgbStops()
+	return err
+}
+
+// ValidateGradients checks the gradients of the config once its theme is set, since stops can be theme slots.
+func ValidateGradients(config Config) error {
+	gradients := []Gradient{config.Ascii.Gradient, config.Header.Gradient, config.Footer.Gradient}
+	for i, name := range []string{"ascii", "header", "footer"} {
+		if err := gradients[i].validate(); err != nil {
+			return fmt.Errorf("invalid %s.gradient: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (gradient Gradient) rgbStops() ([][3]int, error) {
+	stops := make([][3]int, len(gradient.Stops))
+	for i, stop := range gradient.Stops {
+		parameter, err := colorParameter(strings.ToLower(strings.Join(strings.Fields(stop), "")))
+		if err != nil {
+			return nil, fmt.Errorf("gradient stop %q: %w", stop, err)
+		}
+		channels, found := strings.CutPrefix(parameter, "38;2;")
+		values := strings.Split(channels, ";")
+		if !found || len(values) != 3 {
+			return nil, fmt.Errorf("gradient stop %q is not an RGB color", stop)
+		}
+		for j, value := range values {
+			stops[i][j], _ = strconv.Atoi(value)
+		}
+	}
+	return stops, nil
+}
+
+func colorAt(stops [][3]int, t float64) string {
+	segments := len(stops) - 1
+	position := t * float64(segments)
+	i := min(int(position), segments-1)
+	local := position - float64(i)
+
+	mix := func(from, to int) int {
+		return int(math.Round(float64(from) + float64(to-from)*local))
+	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm", mix(stops[i][0], stops[i+1][0]), mix(stops[i][1], stops[i+1][1]), mix(stops[i][2], stops[i+1][2]))
+}
+
+func (gradient Gradient) position(column, row, width, height int) float64 {
+	ratio := func(i, n int) float64 {
+		if n <= 1 {
+			return 0
+		}
+		return float64(min(i, n-1)) / float64(n-1)
+	}
+	switch gradient.Direction {
+	case "vertical":
+		return ratio(row, height)
+	case "diagonal":
+		return (ratio(column, width) + ratio(row, height)) / 2
+	}
+	return ratio(column, width)
+}
+
+func (gradient Gradient) paint(lines []string) []string {
+	if !gradient.enabled() {
+		return lines
+	}
+	stops, err := gradient.rgbStops()
+	if err != nil {
+		return lines
+	}
+	width := GetMaxLineWidth(lines)
+	painted := make([]string, len(lines))
+	for row, line := range lines {
+		var builder strings.Builder
+		column := 0
+		// One color per grapheme cluster, so combining marks and emoji sequences stay whole
+		graphemes := uniseg.NewGraphemes(StripAnsiCodes(line))
+		for graphemes.Next() {
+			cluster := graphemes.Str()
+			if cluster != " " {
+				builder.WriteString(colorAt(stops, gradient.position(column, row, width, len(lines))))
+			}
+			builder.WriteString(cluster)
+			column += DisplayWidth(cluster)
+		}
+		painted[row] = builder.String()
+	}
+	return painted
+}
+
+// PaintAsciiArt colors the placeholders of the art with ascii.colors, or the whole art with ascii.gradient.
+func PaintAsciiArt(asciiArt string, ascii AsciiConfig) string {
+	if !ascii.Gradient.enabled() {
+		return colorPlaceholders(asciiArt, ascii.Colors, ascii.placeholder())
+	}
+	// The placeholders are dropped, trailing newlines do not count in the height of the art
+	art := StripAnsiCodes(colorPlaceholders(asciiArt, nil, ascii.placeholder()))
+	trimmed := strings.TrimRight(art, "\n")
+	lines := ascii.Gradient.paint(strings.Split(trimmed, "\n"))
+	return strings.Join(lines, "\n") + art[len(trimmed):]
+}
@@ -0,0 +1,240 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GridConfig lays out the items of the grid menu type.
+type GridConfig struct {
+	Columns   int    `json:"columns"`
+	Order     string `json:"order"`
+	Separator string `json:"separator"`
+	Boxed     bool   `json:"boxed"`
+}
+
+func (grid GridConfig) withDefaults() GridConfig {
+	if grid.Columns <= 0 {
+		grid.Columns = 2
+	}
+	if grid.Order != "column" {
+		grid.Order = "row"
+	}
+	if grid.Separator == "" {
+		grid.Separator = " │ "
+	}
+	return grid
+}
+
+func (grid GridConfig) cellWidth(width int) int {
+	if width <= 0 {
+		return 0
+	}
+	if grid.Boxed {
+		// "│ " before each cell, " " after it and the closing "│"
+		return max(1, (width-1)/grid.Columns-3)
+	}
+	return max(1, (width-(grid.Columns-1)*DisplayWidth(grid.Separator))/grid.Columns)
+}
+
+type gridCell struct {
+	item  ConfigItem
+	value string
+	color string
+}
+
+func gridCells(config Config, items map[string]Value, labelWidth int, cellWidth int) []gridCell {
+	cells := []gridCell{}
+	for _, item := range config.Items {
+		value := itemValue(item, items)
+		if groupSkipped(item, value) {
+			continue
+		}
+		lines := fitValue(item, value, cellWidth, labelWidth+padding)
+		if lines == nil {
+			continue
+		}
+		// Cells have no room for continuation lines
+		if len(lines) > 1 {
+			lines = []string{TruncateToWidth(value, cellWidth-labelWidth-padding)}
+		}
+		cells = append(cells, gridCell{item: item, value: lines[0], color: valueColorCode(item, items)})
+	}
+	return cells
+}
+
+func gridSections(cells []gridCell) [][]gridCell {
+	sections := [][]gridCell{}
+	for i, cell := range cells {
+		if i == 0 || cells[i-1].item.Group != cell.item.Group {
+			sections = append(sections, []gridCell{})
+		}
+		sections[len(sections)-1] = append(sections[len(sections)-1], cell)
+	}
+	return sections
+}
+
+func gridRows(cells []gridCell, grid GridConfig) [][]*gridCell {
+	columnCount := min(grid.Columns, len(cells))
+	rowCount := (len(cells) + columnCount - 1) / columnCount
+	if grid.Order == "column" {
+		// Filling columns first can leave the last ones empty
+		columnCount = (len(cells) + rowCount - 1) / rowCount
+	}
+	rows := make([][]*gridCell, rowCount)
+	for i := range rows {
+		rows[i] = make([]*gridCell, columnCount)
+	}
+	for i := range cells {
+		if grid.Order == "column" {
+			rows[i%rowCount][i/rowCount] = &cells[i]
+		} else {
+			rows[i/columnCount][i%columnCount] = &cells[i]
+		}
+	}
+	return rows
+}
+
+func formatGridCells(rows [][]*gridCell, iconWidth int) [][]string {
+	formatted := make([][]string, len(rows))
+	for i := range rows {
+		formatted[i] = make([]string, len(rows[i]))
+	}
+	for column := range len(rows[0]) {
+		textWidth := 0
+		for _, row := range rows {
+			if row[column] != nil {
+				textWidth = max(textWidth, DisplayWidth(row[column].item.Text))
+			}
+		}
+		for i, row := range rows {
+			cell := row[column]
+			if cell == nil {
+				continue
+			}
+			iconString := fmt.Sprintf("%s%s%s", GetColorCode(cell.item.IconColor), PadRight(cell.item.Icon, iconWidth), Reset)
+			textString := fmt.Sprintf("%s%s%s", GetColorCode(cell.item.TextColor), cell.item.Text, Reset)
+			textPadding := strings.Repeat(" ", textWidth-DisplayWidth(cell.item.Text)+padding)
+			formatted[i][column] = fmt.Sprintf("%s%s%s%s%s%s", iconString, textString, textPadding, cell.color, cell.value, Reset)
+		}
+	}
+	return formatted
+}
+
+func gridColumnWidths(cells [][]string) []int {
+	widths := make([]int, len(cells[0]))
+	for _, row := range cells {
+		for column, cell := range row {
+			widths[column] = max(widths[column], DisplayWidth(cell))
+		}
+	}
+	return widths
+}
+
+func gridBorder(config Config, widths []int, left string, middle string, right string) string {
+	segments := make([]string, len(widths))
+	for i, width := range widths {
+		segments[i] = RepeatToWidth(GetBorderStyle(config.General.Border).Horizontal, width+padding)
+	}
+	return paintBorder(config, left+strings.Join(segments, middle)+right)
+}
+
+func buildGridLines(config Config, cells [][]string, grid GridConfig) []string {
+	style := GetBorderStyle(config.General.Border)
+	widths := gridColumnWidths(cells)
+	if grid.Boxed {
+		for i := range widths {
+			widths[i] = roundToBorderWidth(widths[i]+padding, style) - padding
+		}
+	}
+
+	lines := []string{}
+	if grid.Boxed {
+		lines = append(lines, gridBorder(config, widths, style.TopLeft, style.TopT, style.TopRight))
+	}
+	for i, row := range cells {
+		if grid.Boxed && i > 0 {
+			lines = append(lines, gridBorder(config, widths, style.LeftT, style.Cross, style.RightT))
+		}
+		padded := make([]string, len(row))
+		for column, cell := range row {
+			padded[column] = PadRight(cell, widths[column])
+		}
+		if grid.Boxed {
+			vertical := paintBorder(config, style.Vertical)
+			lines = append(lines, vertical+" "+strings.Join(padded, " "+vertical+" ")+" "+vertical)
+			continue
+		}
+		// The last row stops after its last cell, without separators or padding
+		for len(row) > 1 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		padded = padded[:len(row)]
+		padded[len(row)-1] = row[len(row)-1]
+		lines = append(lines, strings.Join(padded, grid.Separator))
+	}
+	if grid.Boxed {
+		lines = append(lines, gridBorder(config, widths, style.BottomLeft, style.BottomT, style.BottomRight))
+	}
+	return lines
+}
+
+func BuildGridMenu(items map[string]Value, asciiArt string, config Config) string {
+	config = applyDynamicIcons(config, items)
+	config = applyTemplates(config, DisplayValues(items))
+
+	grid := config.General.Grid.withDefaults()
+	asciiColors := GetColorCode(config.Ascii.Colors.Base())
+	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+
+	paddedAsciiArt := AddPaddingToMultilineString(PaintAsciiArt(asciiArt, config.Ascii), config.Ascii.HorizontalPadding, config.Ascii.VerticalPadding)
+	menu := ""
+
+	if config.Ascii.Position == "top" {
+		menu += fmt.Sprintf("%s%s%s\n", asciiColors, paddedAsciiArt, Reset)
+	}
+
+	width := menuWidth(config, paddedAsciiArt)
+	if width > 0 {
+		width = max(1, width-config.General.MenuPadding)
+	}
+
+	iconWidth := iconColumnWidth(config.Items)
+	textWidth := 0
+	for _, item := range config.Items {
+		textWidth = max(textWidth, DisplayWidth(item.Text))
+	}
+
+	sections := gridSections(gridCells(config, items, iconWidth+textWidth, grid.cellWidth(width)))
+	blocks := make([][]string, len(sections))
+	lineWidth := DefineBoxBorder(config)
+	for i, section := range sections {
+		blocks[i] = buildGridLines(config, formatGridCells(gridRows(section, grid), iconWidth), grid)
+		lineWidth = max(lineWidth, GetMaxLineWidth(blocks[i]))
+	}
+
+	if config.Header.Enabled {
+		menu += buildListHeader(config, lineWidth)
+	}
+	for i, block := range blocks {
+		menu += buildListGroup(config, sections[i][0].item.Group, i == 0, lineWidth)
+		for _, line := range block {
+			menu += fmt.Sprintf("%s%s\n", menuPadding, line)
+		}
+	}
+	if config.Footer.Enabled {
+		menu += buildListFooter(config, lineWidth)
+	}
+
+	if config.Ascii.Position == "left" {
+		menu = combineAsciiAndMenuLeft(menu, paddedAsciiArt, asciiColors)
+	} else if config.Ascii.Position == "right" {
+		menu = combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors)
+	}
+
+	if config.Ascii.Position == "bottom" {
+		menu += fmt.Sprintf("%s%s%s\n", asciiColors, paddedAsciiArt, Reset)
+	}
+
+	return menu
+}
@@ -0,0 +1,109 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ItemGroup is a titled section of items, written as {"group": "Hardware", "items": [...]} in the config.
+type ItemGroup struct {
+	Title     string       `json:"group"`
+	TextColor string       `json:"text_color"`
+	LineColor string       `json:"line_color"`
+	Collapse  bool         `json:"collapse"`
+	Items     []ConfigItem `json:"items"`
+}
+
+// ConfigItems are the items of the config. Groups are flattened when the config is read,
+// their items keep a pointer to the group they belong to.
+type ConfigItems []ConfigItem
+
+func (items *ConfigItems) UnmarshalJSON(data []byte) error {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	flattened := ConfigItems{}
+	for _, entry := range entries {
+		var probe struct {
+			Group *string `json:"group"`
+		}
+		if err := json.Unmarshal(entry, &probe); err != nil {
+			return err
+		}
+		if probe.Group == nil {
+			var item ConfigItem
+			if err := json.Unmarshal(entry, &item); err != nil {
+				return err
+			}
+			flattened = append(flattened, item)
+			continue
+		}
+
+		group := &ItemGroup{}
+		if err := json.Unmarshal(entry, group); err != nil {
+			return err
+		}
+		for _, item := range group.Items {
+			item.Group = group
+			flattened = append(flattened, item)
+		}
+		group.Items = nil
+	}
+	*items = flattened
+	return nil
+}
+
+// groupSkipped reports whether an item without value is left out of its collapsing group.
+// A group whose items are all left out or hidden is not shown at all.
+func groupSkipped(item ConfigItem, value string) bool {
+	return item.Group != nil && item.Group.Collapse && (strings.TrimSpace(value) == "" || value == defaultConfigValue)
+}
+
+func groupTitleWidth(configItems []ConfigItem) int {
+	width := 0
+	for _, item := range configItems {
+		if item.Group != nil {
+			width = max(width, DisplayWidth(item.Group.Title))
+		}
+	}
+	return width
+}
+
+func groupLineColor(group *ItemGroup) string {
+	if group == nil {
+		return ""
+	}
+	return group.LineColor
+}
+
+func buildBoxGroup(config Config, group *ItemGroup, first bool, borderWidth int) string {
+	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	style := GetBorderStyle(config.General.Border)
+	lines := ""
+	if !first {
+		border := RepeatToWidth(style.Horizontal, borderWidth)
+		lineColor := lineColorCode(config, groupLineColor(group))
+		lines += fmt.Sprintf("%s%s%s%s%s%s\n", menuPadding, paintBorder(config, style.LeftT), lineColor, border, Reset, paintBorder(config, style.RightT))
+	}
+	if group != nil {
+		vertical := paintBorder(config, style.Vertical)
+		lines += fmt.Sprintf("%s%s %s%s%s %s\n", menuPadding, vertical, GetColorCode(group.TextColor), PadRight(group.Title, borderWidth-padding), Reset, vertical)
+	}
+	return lines
+}
+
+func buildListGroup(config Config, group *ItemGroup, first bool, lineWidth int) string {
+	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	lines := ""
+	if !first {
+		line := RepeatToWidth(GetBorderStyle(config.General.Border).Horizontal, lineWidth)
+		lines += fmt.Sprintf("%s%s%s%s\n", menuPadding, lineColorCode(config, groupLineColor(group)), line, Reset)
+	}
+	if group != nil {
+		lines += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(group.TextColor), group.Title, Reset)
+	}
+	return lines
+}
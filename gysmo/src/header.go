@@ -0,0 +1,67 @@
+package src
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func headerLines(text string) []string {
+	return strings.Split(text, "\n")
+}
+
+func paintedHeaderLines(text string, gradient Gradient) []string {
+	return gradient.paint(headerLines(text))
+}
+
+func headerWidth(text string, inBorder bool, horizontal string, borderLine int) int {
+	lines := headerLines(text)
+	width := 0
+	if inBorder {
+		width = DisplayWidth(lines[borderLine]) + padding + 2*DisplayWidth(horizontal)
+		lines = slices.Delete(lines, borderLine, borderLine+1)
+	}
+	for _, line := range lines {
+		width = max(width, DisplayWidth(line)+padding)
+	}
+	return width
+}
+
+func alignText(text string, width int, align string) string {
+	space := max(0, width-DisplayWidth(text))
+	switch align {
+	case "center":
+		return strings.Repeat(" ", space/2) + text + strings.Repeat(" ", space-space/2)
+	case "right":
+		return strings.Repeat(" ", space) + text
+	}
+	return text + strings.Repeat(" ", space)
+}
+
+func titledBorder(style BorderStyle, title string, width int, align string) (string, string, string) {
+	horizontal := style.Horizontal
+	cell := DisplayWidth(horizontal)
+	title = " " + title + " "
+	title = PadRight(title, roundToBorderWidth(DisplayWidth(title), style))
+	fill := max(0, width-DisplayWidth(title))
+	before := cell
+	switch align {
+	case "center":
+		before = fill / 2 / cell * cell
+	case "right":
+		before = fill - cell
+	}
+	before = max(0, before)
+	return RepeatToWidth(horizontal, before), title, RepeatToWidth(horizontal, fill-before)
+}
+
+func titledBoxBorder(config Config, left string, right string, borderWidth int, title string, titleColor string, align string) string {
+	before, title, after := titledBorder(GetBorderStyle(config.General.Border), title, borderWidth, align)
+	return paintBorder(config, left+before) + GetColorCode(titleColor) + title + Reset + paintBorder(config, after+right)
+}
+
+func titledLine(config Config, lineColor string, lineWidth int, title string, titleColor string, align string) string {
+	before, title, after := titledBorder(GetBorderStyle(config.General.Border), title, lineWidth, align)
+	lineCode := lineColorCode(config, lineColor)
+	return fmt.Sprintf("%s%s%s%s%s%s%s%s%s", lineCode, before, Reset, GetColorCode(titleColor), title, Reset, lineCode, after, Reset)
+}
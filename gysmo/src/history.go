@@ -0,0 +1,157 @@
+package src
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHistorySamples   = 50
+	defaultSparklineSamples = 10
+)
+
+var sparklineBlocks = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// Sample is one value of a numeric keyword, recorded on each run in data/history.json.
+type Sample struct {
+	Value float64   `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+// SparklineOptions holds the "sparkline" options of an item displayed as a sparkline.
+type SparklineOptions struct {
+	Samples int    `json:"samples"`
+	Value   string `json:"value"`
+}
+
+var historyMu sync.Mutex
+
+func historyPath() string {
+	return filepath.Join(LoadWorkingPath(), "data", "history.json")
+}
+
+// LoadHistory returns the recorded samples of every numeric keyword, oldest first.
+func LoadHistory() map[string][]Sample {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	return readHistoryFile()
+}
+
+func readHistoryFile() map[string][]Sample {
+	history := make(map[string][]Sample)
+	data, err := ReadFile(historyPath())
+	if err != nil {
+		return history
+	}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return make(map[string][]Sample)
+	}
+	return history
+}
+
+// RecordHistory appends the numeric values to the history and keeps the last samples of each keyword.
+// A negative number of samples disables the history.
+func RecordHistory(items map[string]Value, config HistoryConfig) map[string][]Sample {
+	if config.Samples < 0 {
+		return map[string][]Sample{}
+	}
+	size := defaultHistorySamples
+	if config.Samples > 0 {
+		size = config.Samples
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	history := readHistoryFile()
+	now := time.Now()
+	for key, value := range items {
+		if !value.Numeric || value.Err != "" {
+			continue
+		}
+		samples := append(history[key], Sample{Value: value.Raw, Time: now})
+		if len(samples) > size {
+			samples = samples[len(samples)-size:]
+		}
+		history[key] = samples
+	}
+
+	path := historyPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return history
+	}
+	if data, err := json.MarshalIndent(history, "", "  "); err == nil {
+		os.WriteFile(path, data, 0644)
+	}
+	return history
+}
+
+func attachHistory(items map[string]Value, history map[string][]Sample) {
+	for key, value := range items {
+		samples, exists := history[key]
+		if !exists {
+			continue
+		}
+		value.History = make([]float64, len(samples))
+		for i, sample := range samples {
+			value.History[i] = sample.Value
+		}
+		items[key] = value
+	}
+}
+
+// RenderSparkline draws one block per sample. Percentages use a fixed 0-100 scale,
+// other values are scaled between their lowest and highest sample.
+func RenderSparkline(samples []float64, percent bool) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	low, high := 0.0, 100.0
+	if !percent {
+		low, high = samples[0], samples[0]
+		for _, sample := range samples {
+			low = math.Min(low, sample)
+			high = math.Max(high, sample)
+		}
+	}
+
+	var builder strings.Builder
+	for _, sample := range samples {
+		level := len(sparklineBlocks) / 2
+		if high > low {
+			ratio := (math.Max(low, math.Min(high, sample)) - low) / (high - low)
+			level = int(math.Round(ratio * float64(len(sparklineBlocks)-1)))
+		}
+		builder.WriteString(sparklineBlocks[level])
+	}
+	return builder.String()
+}
+
+func sparklineValue(value Value, item ConfigItem) string {
+	var options SparklineOptions
+	if item.Sparkline != nil {
+		options = *item.Sparkline
+	}
+	count := defaultSparklineSamples
+	if options.Samples > 0 {
+		count = options.Samples
+	}
+	samples := value.History
+	if len(samples) > count {
+		samples = samples[len(samples)-count:]
+	}
+
+	sparkline := RenderSparkline(samples, value.Unit == "%")
+	switch options.Value {
+	case "none":
+		return sparkline
+	case "left":
+		return value.Format(item.Format) + " " + sparkline
+	}
+	return sparkline + " " + value.Format(item.Format)
+}
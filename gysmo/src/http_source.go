@@ -0,0 +1,148 @@
+package src
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/xeipuuv/gojsonpointer"
+)
+
+const defaultHTTPSourceCacheTTL = time.Minute
+
+var headerEnvVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// GetHTTPValue fetches a JSON document and returns the value found at the JSON pointer of the source.
+func GetHTTPValue(source HTTPSource) Value {
+	key := ConfigItem{HTTP: &source}.Key()
+
+	ttl := defaultHTTPSourceCacheTTL
+	if source.CacheTTL != 0 {
+		ttl = time.Duration(source.CacheTTL) * time.Second
+	}
+
+	cached, hasCache := ReadCache(key)
+	if hasCache && cached.Fresh(ttl) {
+		return saveSourceValue(key, ParseValue(cached.Value))
+	}
+
+	text, err := fetchHTTPValue(source)
+	if err != nil {
+		if hasCache {
+			return saveSourceValue(key, ParseValue(cached.Value))
+		}
+		return saveSourceValue(key, ErrorValue(err))
+	}
+
+	if ttl > 0 {
+		SaveCache(key, text)
+	}
+	return saveSourceValue(key, ParseValue(text))
+}
+
+func saveSourceValue(key string, value Value) Value {
+	SaveValuesToFile(map[string]Value{key: value})
+	return value
+}
+
+func fetchHTTPValue(source HTTPSource) (string, error) {
+	timeout := httpTimeout
+	if source.Timeout > 0 {
+		timeout = time.Duration(source.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", source.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range source.Headers {
+		req.Header.Set(name, expandHeader(value))
+	}
+
+	// The context bounds the request, the client has no timeout of its own
+	resp, err := HttpSourceDo(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected status from %s: %s", source.URL, resp.Status)
+	}
+
+	body, err := ReadResponse(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var document any
+	if err := json.Unmarshal(body, &document); err != nil {
+		return "", fmt.Errorf("invalid JSON from %s: %w", source.URL, err)
+	}
+
+	pointer, err := gojsonpointer.NewJsonPointer(source.Pointer)
+	if err != nil {
+		return "", err
+	}
+	value, _, err := pointer.Get(document)
+	if err != nil {
+		return "", err
+	}
+	return FormatJSONValue(value)
+}
+
+// headersKey tells apart sources that only differ by their headers in the cache and the data file.
+// The headers are hashed so tokens are not written to disk.
+func (source HTTPSource) headersKey() string {
+	if len(source.Headers) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(source.Headers))
+	for name := range source.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	hash := fnv.New64a()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s: %s\n", name, source.Headers[name])
+	}
+	return fmt.Sprintf(" %x", hash.Sum64())
+}
+
+// expandHeader replaces ${VAR} in a header value with the environment variable, e.g. "Bearer ${HASS_TOKEN}".
+// Any other $ is kept, so tokens containing one are sent as they are.
+func expandHeader(value string) string {
+	return headerEnvVar.ReplaceAllStringFunc(value, func(reference string) string {
+		name := headerEnvVar.FindStringSubmatch(reference)[1]
+		variable, _ := LookupEnv(name)
+		return variable
+	})
+}
+
+// FormatJSONValue renders a decoded JSON value for display. Objects and arrays stay in JSON form.
+func FormatJSONValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "null", nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,163 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultForeground = "\033[39m"
+
+// InlineConfig lays out the inline menu type, a single line for prompts and status bars.
+type InlineConfig struct {
+	Style          string   `json:"style"`
+	Separator      string   `json:"separator"`
+	SeparatorColor string   `json:"separator_color"`
+	Backgrounds    []string `json:"backgrounds"`
+	MaxWidth       int      `json:"max_width"`
+}
+
+func (inline InlineConfig) withDefaults() InlineConfig {
+	if inline.Style != "powerline" {
+		inline.Style = "plain"
+	}
+	if inline.Separator == "" {
+		if inline.Style == "powerline" {
+			inline.Separator = "\ue0b0"
+		} else {
+			inline.Separator = " | "
+		}
+	}
+	return inline
+}
+
+type inlineSegment struct {
+	item       ConfigItem
+	label      string
+	labelColor string
+	value      string
+	valueColor string
+}
+
+func (segment inlineSegment) text() string {
+	if segment.label == "" {
+		return segment.value
+	}
+	return segment.label + " " + segment.value
+}
+
+func (inline InlineConfig) width(segment inlineSegment, first bool) int {
+	width := DisplayWidth(segment.text())
+	if inline.Style == "powerline" {
+		// Spaces around the text and the arrow after it
+		return width + padding + DisplayWidth(inline.Separator)
+	}
+	if !first {
+		width += DisplayWidth(inline.Separator)
+	}
+	return width
+}
+
+func inlineColor(code string) string {
+	if code == Reset {
+		return defaultForeground
+	}
+	return code
+}
+
+func inlineSegments(config Config, items map[string]Value) []inlineSegment {
+	segments := []inlineSegment{}
+	for _, item := range config.Items {
+		value := itemValue(item, items)
+		if groupSkipped(item, value) {
+			continue
+		}
+		segment := inlineSegment{
+			item:       item,
+			label:      item.Icon,
+			labelColor: GetColorCode(item.IconColor),
+			value:      value,
+			valueColor: valueColorCode(item, items),
+		}
+		if item.Icon == "" {
+			segment.label = item.Text
+			segment.labelColor = GetColorCode(item.TextColor)
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func fitInlineSegments(segments []inlineSegment, inline InlineConfig, budget int) []inlineSegment {
+	if budget <= 0 {
+		return segments
+	}
+	fitted := []inlineSegment{}
+	used := 0
+	for _, segment := range segments {
+		width := inline.width(segment, len(fitted) == 0)
+		if used+width <= budget {
+			fitted = append(fitted, segment)
+			used += width
+			continue
+		}
+		if segment.item.Overflow == "hide" {
+			continue
+		}
+		available := budget - used - (width - DisplayWidth(segment.value))
+		if available > DisplayWidth(ellipsis) {
+			segment.value = TruncateToWidth(segment.value, available)
+			fitted = append(fitted, segment)
+		}
+		break
+	}
+	return fitted
+}
+
+func buildPlainLine(segments []inlineSegment, inline InlineConfig) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = fmt.Sprintf("%s%s%s", segment.valueColor, segment.value, Reset)
+		if segment.label != "" {
+			parts[i] = fmt.Sprintf("%s%s%s %s", segment.labelColor, segment.label, Reset, parts[i])
+		}
+	}
+	separator := fmt.Sprintf("%s%s%s", GetColorCode(inline.SeparatorColor), inline.Separator, Reset)
+	return strings.Join(parts, separator)
+}
+
+func buildPowerlineLine(segments []inlineSegment, inline InlineConfig) string {
+	background := func(i int) string {
+		if len(inline.Backgrounds) == 0 || i >= len(segments) {
+			return ""
+		}
+		return inline.Backgrounds[i%len(inline.Backgrounds)]
+	}
+
+	line := ""
+	for i, segment := range segments {
+		line += GetBackgroundColorCode(background(i)) + " "
+		if segment.label != "" {
+			line += inlineColor(segment.labelColor) + segment.label + " "
+		}
+		line += inlineColor(segment.valueColor) + segment.value + " " + Reset
+		line += inlineColor(GetColorCode(background(i))) + GetBackgroundColorCode(background(i+1)) + inline.Separator + Reset
+	}
+	return line
+}
+
+func BuildInlineMenu(items map[string]Value, config Config) string {
+	config = applyDynamicIcons(config, items)
+	config = applyTemplates(config, DisplayValues(items))
+
+	inline := config.General.Inline.withDefaults()
+	budget := inline.MaxWidth
+	if budget <= 0 {
+		budget = config.General.Width
+	}
+
+	segments := fitInlineSegments(inlineSegments(config, items), inline, budget)
+	if inline.Style == "powerline" {
+		return buildPowerlineLine(segments, inline)
+	}
+	return buildPlainLine(segments, inline)
+}
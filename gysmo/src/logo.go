@@ -0,0 +1,88 @@
+package src
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed logos
+var logos embed.FS
+
+// The colors of the built-in logos, used when ascii.colors is not set.
+var logoColors = map[string]AsciiColors{
+	"alpine":    {"blue", "white"},
+	"arch":      {"cyan"},
+	"debian":    {"red"},
+	"fedora":    {"blue", "white"},
+	"gentoo":    {"purple", "white"},
+	"linux":     {"bright_black", "white", "yellow"},
+	"linuxmint": {"green", "white"},
+	"manjaro":   {"green"},
+	"nixos":     {"blue", "cyan"},
+	"opensuse":  {"green", "white"},
+	"ubuntu":    {"red"},
+}
+
+func logoCandidates(osRelease OSRelease) []string {
+	candidates := []string{}
+	for _, id := range append([]string{osRelease.ID}, strings.Fields(osRelease.ID_LIKE)...) {
+		id = strings.ToLower(id)
+		if id == "" || strings.ContainsAny(id, `/\`) {
+			continue
+		}
+		candidates = append(candidates, id)
+		// opensuse-tumbleweed and opensuse-leap use the opensuse logo
+		if family, _, found := strings.Cut(id, "-"); found {
+			candidates = append(candidates, family)
+		}
+	}
+	return append(candidates, "linux")
+}
+
+// LoadAsciiArt reads the ASCII art of ascii.path in asciiDir. With "auto" it picks the logo of the distribution,
+// the small one when ascii.size is "small", and a file named after the logo in asciiDir overrides the built-in one.
+// It also returns the colors of the art: ascii.colors, or the colors of the built-in logo when it is not set.
+func LoadAsciiArt(asciiDir string, ascii AsciiConfig, osRelease OSRelease) (string, AsciiColors, error) {
+	if ascii.Path != "auto" {
+		asciiArt, err := ReadAsciiArt(filepath.Join(asciiDir, ascii.Path))
+		return asciiArt, ascii.Colors, err
+	}
+
+	for _, name := range logoCandidates(osRelease) {
+		files := []string{name}
+		if ascii.Size == "small" {
+			files = []string{name + "_small", name}
+		}
+		asciiArt, found, err := readLogo(asciiDir, files)
+		if err != nil {
+			return "", nil, err
+		}
+		if !found {
+			continue
+		}
+		if ascii.Colors.isEmpty() {
+			return asciiArt, logoColors[name], nil
+		}
+		return asciiArt, ascii.Colors, nil
+	}
+	// The linux logo is built in, so this only happens with a broken build
+	return "", ascii.Colors, nil
+}
+
+func readLogo(asciiDir string, files []string) (string, bool, error) {
+	for _, file := range files {
+		path := filepath.Join(asciiDir, file)
+		if _, err := os.Stat(path); err == nil {
+			asciiArt, err := ReadAsciiArt(path)
+			return asciiArt, true, err
+		}
+	}
+	for _, file := range files {
+		if asciiArt, err := logos.ReadFile("logos/" + file); err == nil {
+			return string(asciiArt), true, nil
+		}
+	}
+	return "", false, nil
+}
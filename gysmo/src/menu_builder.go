@@ -3,8 +3,6 @@ package src
 import (
 	"fmt"
 	"strings"
-
-	"github.com/mattn/go-runewidth"
 )
 
 const padding = 2
@@ -12,7 +10,7 @@ const padding = 2
 func GetMaxIconLength(items []ConfigItem) int {
 	maxIconLength := 0
 	for _, item := range items {
-		iconLength := len(StripAnsiCodes(item.Icon))
+		iconLength := DisplayWidth(item.Icon)
 		if iconLength > maxIconLength {
 			maxIconLength = iconLength
 		}
@@ -20,6 +18,13 @@ func GetMaxIconLength(items []ConfigItem) int {
 	return maxIconLength
 }
 
+func iconColumnWidth(items []ConfigItem) int {
+	if width := GetMaxIconLength(items); width > 0 {
+		return width + 1
+	}
+	return 0
+}
+
 func AddPaddingToMultilineString(s string, horizontalPadding int, verticalPadding int) string {
 	lines := strings.Split(s, "\n")
 	for i, line := range lines {
@@ -32,136 +37,171 @@ func AddPaddingToMultilineString(s string, horizontalPadding int, verticalPaddin
 func DefineBoxBorder(config Config) int {
 	borderWidth := 0
 
-	maxIconLength := GetMaxIconLength(config.Items)
+	iconWidth := iconColumnWidth(config.Items)
 
 	for _, item := range config.Items {
-		maxitemLength := len(StripAnsiCodes(item.Text)) + maxIconLength + padding
+		maxitemLength := DisplayWidth(item.Text) + iconWidth + padding
 		if maxitemLength > borderWidth {
 			borderWidth = maxitemLength
 		}
 	}
 
+	if titleLength := groupTitleWidth(config.Items) + padding; titleLength > borderWidth {
+		borderWidth = titleLength
+	}
+
+	horizontal := GetBorderStyle(config.General.Border).Horizontal
+
+	// The first line of the header goes in the top border, the last line of the footer in the bottom one
 	if config.Header.Enabled {
-		headerLength := len(config.Header.Text)
+		headerLength := headerWidth(config.Header.Text, config.Header.InBorder, horizontal, 0)
 		if headerLength > borderWidth {
-			borderWidth = headerLength + padding
+			borderWidth = headerLength
 		}
 	}
 
 	if config.Footer.Enabled {
-		footerLength := len(config.Footer.Text)
+		footerLength := headerWidth(config.Footer.Text, config.Footer.InBorder, horizontal, len(headerLines(config.Footer.Text))-1)
 		if footerLength > borderWidth {
-			borderWidth = footerLength + padding
+			borderWidth = footerLength
+		}
+	}
+
+	return roundToBorderWidth(borderWidth, GetBorderStyle(config.General.Border))
+}
+
+func applyDynamicIcons(config Config, items map[string]Value) Config {
+	resolved := make([]ConfigItem, len(config.Items))
+	for i, item := range config.Items {
+		if item.Icon == "auto" {
+			item.Icon = items[IconKey(item.Keyword)].Display
 		}
+		resolved[i] = item
 	}
+	config.Items = resolved
+	return config
+}
 
-	return borderWidth
+func itemValue(item ConfigItem, items map[string]Value) string {
+	if value, exists := items[item.Key()]; exists {
+		if item.Display == "bar" && value.Numeric && value.Unit == "%" {
+			return barValue(value, item)
+		}
+		if item.Display == "sparkline" && value.Numeric && len(value.History) > 0 {
+			return sparklineValue(value, item)
+		}
+		return value.Format(item.Format)
+	}
+	return item.Value
 }
 
-func BuildBoxMenu(items map[string]string, asciiArt string, config Config) string {
+func BuildBoxMenu(items map[string]Value, asciiArt string, config Config) string {
+	config = applyDynamicIcons(config, items)
+	config = applyTemplates(config, DisplayValues(items))
 
 	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
 	borderWidth := DefineBoxBorder(config)
-	border := strings.Repeat("─", borderWidth)
+	style := GetBorderStyle(config.General.Border)
+	border := RepeatToWidth(style.Horizontal, borderWidth)
+	top := paintBorder(config, style.TopLeft+border+style.TopRight)
+	if config.Header.Enabled && config.Header.InBorder {
+		title := paintedHeaderLines(config.Header.Text, config.Header.Gradient)[0]
+		top = titledBoxBorder(config, style.TopLeft, style.TopRight, borderWidth, title, config.Header.TextColor, config.Header.Align)
+	}
+	bottom := paintBorder(config, style.BottomLeft+border+style.BottomRight)
+	if config.Footer.Enabled && config.Footer.InBorder {
+		lines := paintedHeaderLines(config.Footer.Text, config.Footer.Gradient)
+		bottom = titledBoxBorder(config, style.BottomLeft, style.BottomRight, borderWidth, lines[len(lines)-1], config.Footer.TextColor, config.Footer.Align)
+	}
 	menu := ""
 
-	asciiColors := GetColorCode(config.Ascii.Colors)
-	paddedAsciiArt := AddPaddingToMultilineString(asciiArt, config.Ascii.HorizontalPadding, config.Ascii.VerticalPadding)
+	asciiColors := GetColorCode(config.Ascii.Colors.Base())
+	paddedAsciiArt := AddPaddingToMultilineString(PaintAsciiArt(asciiArt, config.Ascii), config.Ascii.HorizontalPadding, config.Ascii.VerticalPadding)
 
 	if config.Ascii.Position == "top" {
 		menu += fmt.Sprintf("%s%s%s\n", asciiColors, paddedAsciiArt, Reset)
-		menu += fmt.Sprintf("%s╭%s╮\n", menuPadding, border)
+		menu += fmt.Sprintf("%s%s\n", menuPadding, top)
 	} else {
-		menu += fmt.Sprintf("%s╭%s╮\n", menuPadding, border)
+		menu += fmt.Sprintf("%s%s\n", menuPadding, top)
 	}
 
-	maxIconLength := GetMaxIconLength(config.Items)
+	iconWidth := iconColumnWidth(config.Items)
 
 	if config.Header.Enabled {
 		menu += buildHeader(config, borderWidth, border)
 	}
 
-	menu += buildMenuItems(config, items, borderWidth, maxIconLength)
+	menu += buildMenuItems(config, items, borderWidth, iconWidth, menuWidth(config, paddedAsciiArt))
 
 	if config.Footer.Enabled {
 		menu += buildFooter(config, borderWidth, border)
 	}
 
 	if config.Ascii.Position == "bottom" {
-		menu += fmt.Sprintf("%s╰%s╯\n", menuPadding, border)
+		menu += fmt.Sprintf("%s%s\n", menuPadding, bottom)
 		menu += fmt.Sprintf("%s%s%s\n", asciiColors, paddedAsciiArt, Reset)
 	} else {
-		menu += fmt.Sprintf("%s╰%s╯\n", menuPadding, border)
+		menu += fmt.Sprintf("%s%s\n", menuPadding, bottom)
 	}
 
 	if config.Ascii.Position == "left" {
 		menu = combineAsciiAndMenuLeft(menu, paddedAsciiArt, asciiColors)
 	} else if config.Ascii.Position == "right" {
-		menu = combineAsciiAndMenuRightBox(menu, paddedAsciiArt, asciiColors)
+		menu = combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors)
 	}
 
 	return menu
 }
 
-func BuildListMenu(items map[string]string, asciiArt string, config Config) string {
+func BuildListMenu(items map[string]Value, asciiArt string, config Config) string {
+	config = applyDynamicIcons(config, items)
+	config = applyTemplates(config, DisplayValues(items))
 
 	borderWidth := DefineBoxBorder(config)
-	asciiColors := GetColorCode(config.Ascii.Colors)
+	asciiColors := GetColorCode(config.Ascii.Colors.Base())
 	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
 
-	paddedAsciiArt := AddPaddingToMultilineString(asciiArt, config.Ascii.HorizontalPadding, config.Ascii.VerticalPadding)
+	paddedAsciiArt := AddPaddingToMultilineString(PaintAsciiArt(asciiArt, config.Ascii), config.Ascii.HorizontalPadding, config.Ascii.VerticalPadding)
 	menu := ""
 
-	headerWidth := 0
-	footerWidth := 0
-
 	if config.Ascii.Position == "top" {
 		menu += fmt.Sprintf("%s%s%s\n", asciiColors, paddedAsciiArt, Reset)
 	}
 
 	if config.Header.Enabled {
-		headerWidth = len(config.Header.Text)
-		menu += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Header.TextColor), config.Header.Text, Reset)
-		if config.Header.Line {
-			menu += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Header.LineColor), strings.Repeat("─", borderWidth), Reset)
-		}
+		menu += buildListHeader(config, borderWidth)
 	}
 
-	maxIconLength := GetMaxIconLength(config.Items)
-	formattedItems := formatMenuItems(config, items, borderWidth, maxIconLength)
-
-	maxItemWidth := 0
-	for _, item := range formattedItems {
-		itemWidth := len(StripAnsiCodes(item))
-		if itemWidth > maxItemWidth {
-			maxItemWidth = itemWidth
+	// Items are printed after the menu padding, and side by side when using columns
+	itemWidth := menuWidth(config, paddedAsciiArt)
+	if itemWidth > 0 {
+		itemWidth = max(1, itemWidth-config.General.MenuPadding)
+		if config.General.Columns {
+			itemWidth = max(1, (itemWidth-len(" |  "))/2)
 		}
 	}
 
-	if config.General.Columns {
-		menu += buildColumns(formattedItems, config)
-	} else {
-		for _, item := range formattedItems {
-			menu += fmt.Sprintf("%s%s\n", menuPadding, item)
+	maxIconLength := GetMaxIconLength(config.Items)
+	// Columns are split inside each group
+	for i, section := range formatMenuItems(config, items, borderWidth, maxIconLength, itemWidth) {
+		menu += buildListGroup(config, section.group, i == 0, borderWidth)
+		if config.General.Columns {
+			menu += buildColumns(section.lines, config)
+		} else {
+			for _, item := range section.lines {
+				menu += fmt.Sprintf("%s%s\n", menuPadding, item)
+			}
 		}
 	}
 
 	if config.Footer.Enabled {
-		footerWidth = len(config.Footer.Text)
-		if config.Footer.Line {
-			menu += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Footer.LineColor), strings.Repeat("─", borderWidth), Reset)
-		}
-		menu += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Footer.TextColor), config.Footer.Text, Reset)
+		menu += buildListFooter(config, borderWidth)
 	}
 
 	if config.Ascii.Position == "left" {
 		menu = combineAsciiAndMenuLeft(menu, paddedAsciiArt, asciiColors)
 	} else if config.Ascii.Position == "right" {
-		if config.General.Columns {
-			menu = combineAsciiAndMenuRightColumns(menu, paddedAsciiArt, asciiColors, config, borderWidth, headerWidth, footerWidth)
-		} else {
-			menu = combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors, config, borderWidth, headerWidth, footerWidth)
-		}
+		menu = combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors)
 	}
 
 	if config.Ascii.Position == "bottom" {
@@ -174,8 +214,8 @@ func BuildListMenu(items map[string]string, asciiArt string, config Config) stri
 func GetMaxLineWidth(lines []string) int {
 	maxWidth := 0
 	for _, line := range lines {
-		if len(line) > maxWidth {
-			maxWidth = len(line)
+		if DisplayWidth(line) > maxWidth {
+			maxWidth = DisplayWidth(line)
 		}
 	}
 	return maxWidth
@@ -183,98 +223,197 @@ func GetMaxLineWidth(lines []string) int {
 
 func buildHeader(config Config, borderWidth int, border string) string {
 	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	style := GetBorderStyle(config.General.Border)
+	vertical := paintBorder(config, style.Vertical)
 	header := ""
 	headerColor := GetColorCode(config.Header.TextColor)
-	headerPaddingLength := max(0, borderWidth-len(config.Header.Text)-padding)
-	headerPadding := strings.Repeat(" ", headerPaddingLength)
-	header += fmt.Sprintf("%s│ %s%s%s%s │\n", menuPadding, headerColor, config.Header.Text, headerPadding, Reset)
-	if config.Header.Line {
-		lineColor := GetColorCode(config.Header.LineColor)
-		header += fmt.Sprintf("%s├%s%s%s┤\n", menuPadding, lineColor, border, Reset)
+	lines := paintedHeaderLines(config.Header.Text, config.Header.Gradient)
+	if config.Header.InBorder {
+		lines = lines[1:]
+	}
+	for _, line := range lines {
+		header += fmt.Sprintf("%s%s %s%s%s %s\n", menuPadding, vertical, headerColor, alignText(line, borderWidth-padding, config.Header.Align), Reset, vertical)
+	}
+	// Without lines left the header is only in the border and needs no separator
+	if config.Header.Line && len(lines) > 0 {
+		lineColor := lineColorCode(config, config.Header.LineColor)
+		header += fmt.Sprintf("%s%s%s%s%s%s\n", menuPadding, paintBorder(config, style.LeftT), lineColor, border, Reset, paintBorder(config, style.RightT))
 	}
 	return header
 }
 
 func buildFooter(config Config, borderWidth int, border string) string {
 	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	style := GetBorderStyle(config.General.Border)
+	vertical := paintBorder(config, style.Vertical)
 	footer := ""
-	if config.Footer.Line {
-		lineColor := GetColorCode(config.Footer.LineColor)
-		footer += fmt.Sprintf("%s├%s%s%s┤\n", menuPadding, lineColor, border, Reset)
+	lines := paintedHeaderLines(config.Footer.Text, config.Footer.Gradient)
+	if config.Footer.InBorder {
+		lines = lines[:len(lines)-1]
+	}
+	if config.Footer.Line && len(lines) > 0 {
+		lineColor := lineColorCode(config, config.Footer.LineColor)
+		footer += fmt.Sprintf("%s%s%s%s%s%s\n", menuPadding, paintBorder(config, style.LeftT), lineColor, border, Reset, paintBorder(config, style.RightT))
 	}
 	footerColor := GetColorCode(config.Footer.TextColor)
-	footerPaddingLength := max(0, borderWidth-len(config.Footer.Text)-padding)
-	footerPadding := strings.Repeat(" ", footerPaddingLength)
-	footer += fmt.Sprintf("%s│ %s%s%s%s │\n", menuPadding, footerColor, config.Footer.Text, footerPadding, Reset)
+	for _, line := range lines {
+		footer += fmt.Sprintf("%s%s %s%s%s %s\n", menuPadding, vertical, footerColor, alignText(line, borderWidth-padding, config.Footer.Align), Reset, vertical)
+	}
 	return footer
 }
 
-func buildMenuItems(config Config, items map[string]string, borderWidth int, IconLength int) string {
+func buildListHeader(config Config, lineWidth int) string {
+	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	header := ""
+	lines := paintedHeaderLines(config.Header.Text, config.Header.Gradient)
+	// Like the top border of the box menu, the first line opens the header
+	if config.Header.InBorder {
+		header += fmt.Sprintf("%s%s\n", menuPadding, titledLine(config, config.Header.LineColor, lineWidth, lines[0], config.Header.TextColor, config.Header.Align))
+		lines = lines[1:]
+	}
+	for _, line := range lines {
+		text := strings.TrimRight(alignText(line, lineWidth, config.Header.Align), " ")
+		header += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Header.TextColor), text, Reset)
+	}
+	if config.Header.Line && !config.Header.InBorder {
+		line := RepeatToWidth(GetBorderStyle(config.General.Border).Horizontal, lineWidth)
+		header += fmt.Sprintf("%s%s%s%s\n", menuPadding, lineColorCode(config, config.Header.LineColor), line, Reset)
+	}
+	return header
+}
+
+func buildListFooter(config Config, lineWidth int) string {
+	menuPadding := strings.Repeat(" ", config.General.MenuPadding)
+	footer := ""
+	lines := paintedHeaderLines(config.Footer.Text, config.Footer.Gradient)
+	title := lines[len(lines)-1]
+	if config.Footer.InBorder {
+		lines = lines[:len(lines)-1]
+	}
+	if config.Footer.Line && !config.Footer.InBorder {
+		line := RepeatToWidth(GetBorderStyle(config.General.Border).Horizontal, lineWidth)
+		footer += fmt.Sprintf("%s%s%s%s\n", menuPadding, lineColorCode(config, config.Footer.LineColor), line, Reset)
+	}
+	for _, line := range lines {
+		text := strings.TrimRight(alignText(line, lineWidth, config.Footer.Align), " ")
+		footer += fmt.Sprintf("%s%s%s%s\n", menuPadding, GetColorCode(config.Footer.TextColor), text, Reset)
+	}
+	// Like the bottom border of the box menu, the last line closes the footer
+	if config.Footer.InBorder {
+		footer += fmt.Sprintf("%s%s\n", menuPadding, titledLine(config, config.Footer.LineColor, lineWidth, title, config.Footer.TextColor, config.Footer.Align))
+	}
+	return footer
+}
+
+func buildMenuItems(config Config, items map[string]Value, borderWidth int, IconLength int, maxWidth int) string {
 	menuItems := ""
+	vertical := paintBorder(config, GetBorderStyle(config.General.Border).Vertical)
+	// Wrapped values continue on lines with an empty box
+	continuation := fmt.Sprintf("%s%s %s %s ", strings.Repeat(" ", config.General.MenuPadding), vertical, strings.Repeat(" ", max(0, borderWidth-padding)), vertical)
+	var group *ItemGroup
+	first := true
 	for _, item := range config.Items {
-		value, exists := items[item.Keyword]
-		if exists {
-			value = items[item.Keyword]
-		} else {
-			value = item.Value
+		value := itemValue(item, items)
+		if groupSkipped(item, value) {
+			continue
 		}
+		lines := fitValue(item, value, maxWidth, DisplayWidth(continuation))
+		if lines == nil {
+			continue
+		}
+		// Groups only start with their first visible item, so empty groups are not shown
+		if item.Group != group {
+			menuItems += buildBoxGroup(config, item.Group, first, borderWidth)
+			group = item.Group
+		}
+		first = false
 
 		menuPadding := strings.Repeat(" ", config.General.MenuPadding)
-		textLength := len(StripAnsiCodes(item.Text))
+		textLength := DisplayWidth(item.Text)
 		fixedLength := IconLength + textLength + padding
 		paddingLength := max(0, borderWidth-fixedLength)
 		padding := strings.Repeat(" ", paddingLength)
 		itemTextColor := GetColorCode(item.TextColor)
 		itemIconColor := GetColorCode(item.IconColor)
-		itemValueColor := GetColorCode(item.ValueColor)
+		itemValueColor := valueColorCode(item, items)
 
-		fixedIconSpace := fmt.Sprintf("%-*s", IconLength, item.Icon)
+		fixedIconSpace := PadRight(item.Icon, IconLength)
 		itemString := fmt.Sprintf("%s%s%s", itemIconColor, fixedIconSpace, Reset)
 		itemTextString := fmt.Sprintf("%s%s%s", itemTextColor, item.Text, Reset)
-		itemValueString := fmt.Sprintf("%s%s%s", itemValueColor, value, Reset)
+		itemValueString := fmt.Sprintf("%s%s%s", itemValueColor, lines[0], Reset)
 
 		if IconLength > 0 {
-			menuItems += fmt.Sprintf("%s│ %s%s%s │ %s\n", menuPadding, itemString, itemTextString, padding, itemValueString)
+			menuItems += fmt.Sprintf("%s%s %s%s%s %s %s\n", menuPadding, vertical, itemString, itemTextString, padding, vertical, itemValueString)
 		} else {
-			menuItems += fmt.Sprintf("%s│ %s%s │ %s\n", menuPadding, itemTextString, padding, itemValueString)
+			menuItems += fmt.Sprintf("%s%s %s%s %s %s\n", menuPadding, vertical, itemTextString, padding, vertical, itemValueString)
+		}
+		for _, line := range lines[1:] {
+			menuItems += fmt.Sprintf("%s%s%s%s\n", continuation, itemValueColor, line, Reset)
 		}
 	}
 	return menuItems
 }
 
-func formatMenuItems(config Config, items map[string]string, borderWidth int, IconLength int) []string {
-	formattedItems := []string{}
+// itemSection holds the formatted lines of consecutive visible items of the same group, nil outside of groups.
+type itemSection struct {
+	group *ItemGroup
+	lines []string
+}
+
+func formatMenuItems(config Config, items map[string]Value, borderWidth int, IconLength int, maxWidth int) []itemSection {
+	sections := []itemSection{}
 	for _, item := range config.Items {
-		value, exists := items[item.Keyword]
-		if exists {
-			value = items[item.Keyword]
-		} else {
-			value = item.Value
+		value := itemValue(item, items)
+		if groupSkipped(item, value) {
+			continue
 		}
 
-		fixedLength := IconLength + len(item.Text) + padding
-		paddingLength := borderWidth - fixedLength
+		fixedLength := IconLength + DisplayWidth(item.Text) + padding
+		paddingLength := max(0, borderWidth-fixedLength)
 
 		padding := strings.Repeat(" ", paddingLength)
 		itemTextColor := GetColorCode(item.TextColor)
 		itemIconColor := GetColorCode(item.IconColor)
-		itemValueColor := GetColorCode(item.ValueColor)
+		itemValueColor := valueColorCode(item, items)
 
-		iconString := fmt.Sprintf("%s%s%s", itemIconColor, item.Icon, Reset)
-		valueString := fmt.Sprintf("%s%s%s", itemValueColor, value, Reset)
+		iconString := fmt.Sprintf("%s%s%s", itemIconColor, PadRight(item.Icon, IconLength), Reset)
 		textString := fmt.Sprintf("%s%s%s", itemTextColor, item.Text, Reset)
+		prefix := fmt.Sprintf("%s %s  %s", iconString, textString, padding)
+
+		lines := fitValue(item, value, maxWidth, DisplayWidth(prefix))
+		if lines == nil {
+			continue
+		}
+		// Columns have no room for continuation lines
+		if config.General.Columns && len(lines) > 1 {
+			lines = []string{TruncateToWidth(value, maxWidth-DisplayWidth(prefix))}
+		}
 
-		formattedItem := fmt.Sprintf("%s %s  %s%s", iconString, textString, padding, valueString)
-		formattedItems = append(formattedItems, formattedItem)
+		if len(sections) == 0 || sections[len(sections)-1].group != item.Group {
+			sections = append(sections, itemSection{group: item.Group})
+		}
+		section := &sections[len(sections)-1]
+		section.lines = append(section.lines, fmt.Sprintf("%s%s%s%s", prefix, itemValueColor, lines[0], Reset))
+		for _, line := range lines[1:] {
+			section.lines = append(section.lines, fmt.Sprintf("%s%s%s%s", strings.Repeat(" ", DisplayWidth(prefix)), itemValueColor, line, Reset))
+		}
 	}
 
-	return formattedItems
+	return sections
+}
+
+func menuWidth(config Config, paddedAsciiArt string) int {
+	width := config.General.Width
+	if width > 0 && (config.Ascii.Position == "left" || config.Ascii.Position == "right") {
+		width = max(1, width-GetMaxLineWidth(strings.Split(paddedAsciiArt, "\n"))-2)
+	}
+	return width
 }
 
 func getLengthLeftColumn(leftColumn []string) int {
 	maxLeftLength := 0
 	for _, item := range leftColumn {
-		itemLength := len(StripAnsiCodes(item))
+		itemLength := DisplayWidth(item)
 		if itemLength > maxLeftLength {
 			maxLeftLength = itemLength
 		}
@@ -291,10 +430,8 @@ func buildColumns(formattedItems []string, config Config) string {
 
 	maxDisplayWidth := 0
 	for _, item := range leftColumn {
-		stripped := StripAnsiCodes(item)
-		displayWidth := runewidth.StringWidth(stripped)
-		if displayWidth > maxDisplayWidth {
-			maxDisplayWidth = displayWidth
+		if DisplayWidth(item) > maxDisplayWidth {
+			maxDisplayWidth = DisplayWidth(item)
 		}
 	}
 
@@ -302,10 +439,7 @@ func buildColumns(formattedItems []string, config Config) string {
 		if i < len(rightColumn) {
 			leftItem := leftColumn[i]
 			rightItem := rightColumn[i]
-			strippedLeftItem := StripAnsiCodes(leftItem)
-			leftItemDisplayWidth := runewidth.StringWidth(strippedLeftItem)
-
-			paddingLength := maxDisplayWidth - leftItemDisplayWidth
+			paddingLength := maxDisplayWidth - DisplayWidth(leftItem)
 			padding := strings.Repeat(" ", paddingLength)
 
 			menuLine := fmt.Sprintf("%s%s%s |  %s\n", menuPadding, leftItem, padding, rightItem)
@@ -334,7 +468,7 @@ func combineAsciiAndMenu(menu string, paddedAsciiArt string, asciiColors string,
 	if position == "left" {
 		return combineAsciiAndMenuLeft(menu, paddedAsciiArt, asciiColors)
 	} else if position == "right" {
-		return combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors, config, borderWidth, headerWidth, footerWidth)
+		return combineAsciiAndMenuRight(menu, paddedAsciiArt, asciiColors)
 	}
 
 	menuLines := strings.Split(menu, "\n")
@@ -376,160 +510,28 @@ func combineAsciiAndMenuLeft(menu string, paddedAsciiArt string, asciiColors str
 		asciiLine := asciiLines[i]
 		menuLine := menuLines[i]
 		asciiString := fmt.Sprintf("%s%s%s", asciiColors, asciiLine, Reset)
-		padding := strings.Repeat(" ", max(2, maxAsciiLineWidth-len(StripAnsiCodes(asciiLine))+2))
+		padding := strings.Repeat(" ", max(2, maxAsciiLineWidth-DisplayWidth(asciiLine)+2))
 		combinedLines = append(combinedLines, fmt.Sprintf("%s%s%s", asciiString, padding, menuLine))
 	}
 
 	return strings.Join(combinedLines, "\n")
 }
 
-func combineAsciiAndMenuRight(menu string, paddedAsciiArt string, asciiColors string, config Config, borderWidth int, headerWidth int, footerWidth int) string {
+func combineAsciiAndMenuRight(menu string, paddedAsciiArt string, asciiColors string) string {
 	menuLines := strings.Split(menu, "\n")
 	asciiLines := strings.Split(paddedAsciiArt, "\n")
 	maxLines := max(len(menuLines), len(asciiLines))
 
-	// Calculate the maximum width of the menu lines
-	longestMenuLineWidth := 0
-	for _, line := range menuLines {
-		currentLine := StripAnsiCodes(line)
-		if longestMenuLineWidth < len(currentLine) {
-			longestMenuLineWidth = len(currentLine)
-		}
-	}
+	longestMenuLineWidth := GetMaxLineWidth(menuLines)
 
 	menuLines, asciiLines = menuLinesEqualsAsciiLines(menuLines, asciiLines, maxLines)
 
 	combinedLines := []string{}
-
-	menuPadding := config.General.MenuPadding
-	for i := range maxLines {
-		menuLine := menuLines[i]
-		asciiLine := asciiLines[i]
-		padding := 0
-		line := IsLine(menuLine)
-		headerOrFooter := IsHeaderOrFooter(menuLine, config, i, maxLines)
-		if len(menuLine) == 0 {
-			padding = longestMenuLineWidth
-		} else if line {
-			padding = longestMenuLineWidth - borderWidth - menuPadding
-		} else if headerOrFooter == "Header" {
-			padding = longestMenuLineWidth - headerWidth - menuPadding
-		} else if headerOrFooter == "Footer" {
-			padding = longestMenuLineWidth - footerWidth - menuPadding
-		} else {
-			padding = longestMenuLineWidth - len(StripAnsiCodes(menuLine)) + 2
-		}
-		asciiString := fmt.Sprintf("%s%s%s", asciiColors, asciiLine, Reset)
-		combinedLines = append(combinedLines, fmt.Sprintf("%s%s%s", menuLine, strings.Repeat(" ", padding), asciiString))
-	}
-	return strings.Join(combinedLines, "\n")
-}
-
-func combineAsciiAndMenuRightColumns(menu string, paddedAsciiArt string, asciiColors string, config Config, borderWidth int, headerWidth int, footerWidth int) string {
-	menuLines := strings.Split(menu, "\n")
-	asciiLines := strings.Split(paddedAsciiArt, "\n")
-	maxLines := max(len(menuLines), len(asciiLines))
-
-	// Calculate the maximum width of the menu lines, including header and footer
-	longestMenuLineWidth := 0
-	for _, line := range menuLines {
-		currentLine := StripAnsiCodes(line)
-		if longestMenuLineWidth < len(currentLine) {
-			longestMenuLineWidth = len(currentLine)
-		}
-	}
-
-	menuLines, asciiLines = menuLinesEqualsAsciiLines(menuLines, asciiLines, maxLines)
-
-	combinedLines := []string{}
-
-	for i := range maxLines {
-		menuLine := menuLines[i]
-		asciiLine := asciiLines[i]
-
-		// Check if the current line has two columns by looking for the | delimiter
-		hasTwoColumns := strings.Contains(menuLine, " | ")
-
-		padding := 0
-		line := IsLine(menuLine)
-		headerOrFooter := IsHeaderOrFooter(menuLine, config, i, maxLines)
-		if len(menuLine) == 0 {
-			padding = longestMenuLineWidth
-		} else if hasTwoColumns {
-			padding = longestMenuLineWidth - len(StripAnsiCodes(menuLine)) + 4
-		} else if line {
-			padding = longestMenuLineWidth - borderWidth
-		} else if headerOrFooter == "Header" {
-			padding = longestMenuLineWidth - headerWidth
-		} else if headerOrFooter == "Footer" {
-			padding = longestMenuLineWidth - footerWidth
-		} else {
-			padding = longestMenuLineWidth - len(StripAnsiCodes(menuLine)) + 2
-		}
-
-		// Ensure padding is not negative
-		if padding < 0 {
-			padding = 0
-		}
-
-		asciiString := fmt.Sprintf("%s%s%s", asciiColors, asciiLine, Reset)
-		combinedLines = append(combinedLines, fmt.Sprintf("%s%s%s", menuLine, strings.Repeat(" ", padding), asciiString))
-	}
-
-	return strings.Join(combinedLines, "\n")
-}
-
-func combineAsciiAndMenuRightBox(menu string, paddedAsciiArt string, asciiColors string) string {
-	menuLines := strings.Split(menu, "\n")
-	asciiLines := strings.Split(paddedAsciiArt, "\n")
-	maxLines := max(len(menuLines), len(asciiLines))
-
-	menuLength := 0
-	longestValueLength := 0
-	for _, line := range menuLines {
-		if strings.Contains(line, " │ ") {
-			parts := strings.SplitN(line, " │ ", 4)
-			currentLine := StripAnsiCodes(parts[1])
-			if menuLength < len(currentLine) {
-				menuLength = len(currentLine)
-			}
-			if len(parts) > 2 {
-				valueLength := len(StripAnsiCodes(parts[2]))
-				if valueLength > longestValueLength {
-					longestValueLength = valueLength
-				}
-			}
-		}
-	}
-
-	for len(menuLines) < maxLines {
-		menuLines = append(menuLines, "")
-	}
-	for len(asciiLines) < maxLines {
-		asciiLines = append(asciiLines, "")
-	}
-
-	combinedLines := []string{}
-
 	for i := range maxLines {
 		menuLine := menuLines[i]
-		asciiLine := asciiLines[i]
-		padding := 0
-
-		hasValue := strings.Contains(menuLine, " │ ")
-
-		part := strings.SplitN(menuLine, " │ ", 4)
-		if len(menuLine) == 0 {
-			padding = menuLength + longestValueLength + 4
-		} else if hasValue && len(part) > 2 {
-			valueLine := len(StripAnsiCodes(part[2]))
-			padding = longestValueLength - valueLine + 2
-		} else {
-			padding = longestValueLength + 3
-		}
-		asciiString := fmt.Sprintf("%s%s%s", asciiColors, asciiLine, Reset)
+		padding := longestMenuLineWidth - DisplayWidth(menuLine) + 2
+		asciiString := fmt.Sprintf("%s%s%s", asciiColors, asciiLines[i], Reset)
 		combinedLines = append(combinedLines, fmt.Sprintf("%s%s%s", menuLine, strings.Repeat(" ", padding), asciiString))
 	}
-
 	return strings.Join(combinedLines, "\n")
 }
@@ -0,0 +1,66 @@
+package src
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const ellipsis = "…"
+
+// TruncateToWidth cuts s to width cells, ending with an ellipsis when something was cut.
+func TruncateToWidth(s string, width int) string {
+	return widthCondition.Truncate(s, width, ellipsis)
+}
+
+// WrapToWidth splits s into lines of at most width cells, breaking between words when it can.
+func WrapToWidth(s string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for DisplayWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			head := widthCondition.Truncate(word, width, "")
+			if head == "" {
+				// A single character wider than the line still has to go somewhere
+				_, size := utf8.DecodeRuneInString(word)
+				head = word[:size]
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		if word == "" {
+			continue
+		}
+		if line == "" {
+			line = word
+		} else if DisplayWidth(line)+1+DisplayWidth(word) <= width {
+			line += " " + word
+		} else {
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func fitValue(item ConfigItem, value string, maxWidth int, prefixWidth int) []string {
+	available := maxWidth - prefixWidth
+	if maxWidth <= 0 || DisplayWidth(value) <= available {
+		return []string{value}
+	}
+	available = max(1, available)
+
+	switch item.Overflow {
+	case "hide":
+		return nil
+	case "wrap":
+		return WrapToWidth(value, available)
+	}
+	return []string{TruncateToWidth(value, available)}
+}
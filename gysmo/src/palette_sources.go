@@ -0,0 +1,107 @@
+package src
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// paletteSources holds the SGR parameters of the colors read from external palettes, by reference.
+var paletteSources = map[string]string{}
+
+var paletteReference = regexp.MustCompile(`^(distro|(wal|xrdb):(color([0-9]|1[0-5])|foreground|background|cursor))$`)
+var xresourcesColor = regexp.MustCompile(`^\*\.?(color([0-9]|1[0-5])|foreground|background|cursorcolor)$`)
+
+// LoadPaletteSources reads the palettes that colors can reference: "distro" is ANSI_COLOR from /etc/os-release,
+// "wal:<name>" comes from ~/.cache/wal/colors.json and "xrdb:<name>" from ~/.Xresources.
+func LoadPaletteSources() {
+	paletteSources = map[string]string{}
+	if parameter := distroColorParameter(LoadOsRelease().ANSI_COLOR); parameter != "" {
+		paletteSources["distro"] = parameter
+	}
+	if data, err := ReadFile(ExpandHome("~/.cache/wal/colors.json")); err == nil {
+		addPaletteColors("wal", walColors(data))
+	}
+	if data, err := ReadFile(ExpandHome("~/.Xresources")); err == nil {
+		addPaletteColors("xrdb", xresourcesColors(data))
+	}
+}
+
+func addPaletteColors(source string, colors map[string]string) {
+	for name, color := range colors {
+		if parameter, err := literalColorParameter(strings.ToLower(color)); err == nil {
+			paletteSources[source+":"+name] = parameter
+		}
+	}
+}
+
+// paletteColorParameter resolves a palette reference. Palettes missing on this machine use the default color
+// of the terminal, so a config keeps working without pywal or Xresources.
+func paletteColorParameter(color string) (string, bool) {
+	if !paletteReference.MatchString(color) {
+		return "", false
+	}
+	if parameter, exists := paletteSources[color]; exists {
+		return parameter, true
+	}
+	return strconv.Itoa(colorParameters["default"]), true
+}
+
+func distroColorParameter(ansiColor string) string {
+	parameters := strings.Split(ansiColor, ";")
+	color := ""
+	for i := 0; i < len(parameters); i++ {
+		switch {
+		case parameters[i] == "38" && i+4 < len(parameters) && parameters[i+1] == "2":
+			color = strings.Join(parameters[i:i+5], ";")
+			i += 4
+		case parameters[i] == "38" && i+2 < len(parameters) && parameters[i+1] == "5":
+			color = strings.Join(parameters[i:i+3], ";")
+			i += 2
+		default:
+			if value, err := strconv.Atoi(parameters[i]); err == nil && (value >= 30 && value <= 37 || value >= 90 && value <= 97) {
+				color = parameters[i]
+			}
+		}
+	}
+	return color
+}
+
+func walColors(data []byte) map[string]string {
+	var wal struct {
+		Special map[string]string `json:"special"`
+		Colors  map[string]string `json:"colors"`
+	}
+	if err := json.Unmarshal(data, &wal); err != nil {
+		return nil
+	}
+	colors := map[string]string{}
+	for name, color := range wal.Colors {
+		colors[name] = color
+	}
+	for name, color := range wal.Special {
+		colors[name] = color
+	}
+	return colors
+}
+
+func xresourcesColors(data []byte) map[string]string {
+	colors := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		match := xresourcesColor.FindStringSubmatch(strings.ToLower(strings.TrimSpace(key)))
+		if match == nil {
+			continue
+		}
+		name := match[1]
+		if name == "cursorcolor" {
+			name = "cursor"
+		}
+		colors[name] = strings.TrimSpace(value)
+	}
+	return colors
+}
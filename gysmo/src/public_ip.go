@@ -0,0 +1,163 @@
+package src
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	publicIPKey             = "public ip"
+	defaultPublicIPCacheTTL = 10 * time.Minute
+)
+
+var defaultPublicIPProviders = []PublicIPProvider{
+	{URL: "https://api.ipify.org?format=text", Format: "text"},
+	{URL: "https://ifconfig.co/json", Format: "json", Field: "ip"},
+	{URL: "https://icanhazip.com", Format: "text"},
+}
+
+var (
+	HasDefaultRoute = hasDefaultRoute
+)
+
+// GetPublicIP asks each configured provider in order and returns the first valid address.
+// Results are cached in data/cache.json; when the machine is offline the last known address is used.
+func GetPublicIP(config PublicIPConfig) Value {
+	ttl := defaultPublicIPCacheTTL
+	if config.CacheTTL != 0 {
+		ttl = time.Duration(config.CacheTTL) * time.Second
+	}
+
+	if cached, ok := LoadCache(publicIPKey, ttl); ok {
+		value := TextValue(cached)
+		SaveValuesToFile(map[string]Value{publicIPKey: value})
+		return value
+	}
+
+	if !HasDefaultRoute() {
+		value := TextValue(defaultConfigValue)
+		if entry, exists := ReadCache(publicIPKey); exists {
+			value = TextValue(entry.Value)
+		}
+		SaveValuesToFile(map[string]Value{publicIPKey: value})
+		return value
+	}
+
+	providers := config.Providers
+	if len(providers) == 0 {
+		providers = defaultPublicIPProviders
+	}
+
+	for _, provider := range providers {
+		ip, err := fetchPublicIP(provider)
+		if err != nil {
+			continue
+		}
+		if ttl > 0 {
+			SaveCache(publicIPKey, ip)
+		}
+		value := TextValue(ip)
+		SaveValuesToFile(map[string]Value{publicIPKey: value})
+		return value
+	}
+
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{publicIPKey: value})
+	return value
+}
+
+func fetchPublicIP(provider PublicIPProvider) (string, error) {
+	resp, err := HttpGet(provider.URL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("unexpected status from %s: %s", provider.URL, resp.Status)
+	}
+
+	body, err := ReadResponse(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	value := string(body)
+	if provider.Format == "json" {
+		var document any
+		if err := json.Unmarshal(body, &document); err != nil {
+			return "", fmt.Errorf("invalid JSON from %s: %w", provider.URL, err)
+		}
+		field, err := LookupJSONField(document, provider.Field)
+		if err != nil {
+			return "", err
+		}
+		value = fmt.Sprint(field)
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return "", fmt.Errorf("%s did not return an IP address", provider.URL)
+	}
+	return ip.String(), nil
+}
+
+// LookupJSONField follows a dot separated path such as "data.0.ip" inside a decoded JSON document.
+func LookupJSONField(document any, path string) (any, error) {
+	current := document
+	if path == "" {
+		return current, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, exists := node[part]
+			if !exists {
+				return nil, fmt.Errorf("field %q not found", path)
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("field %q not found", path)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("field %q not found", path)
+		}
+	}
+	return current, nil
+}
+
+// hasDefaultRoute reads the kernel routing tables. When they can't be read
+// (not Linux, restricted /proc) we assume the network is reachable.
+func hasDefaultRoute() bool {
+	ipv4, err4 := ReadFile("/proc/net/route")
+	ipv6, err6 := ReadFile("/proc/net/ipv6_route")
+	if err4 != nil && err6 != nil {
+		return true
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(ipv4)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 1 && fields[1] == "00000000" {
+			return true
+		}
+	}
+
+	scanner = bufio.NewScanner(strings.NewReader(string(ipv6)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 9 && fields[0] == strings.Repeat("0", 32) && fields[1] == "00" && fields[9] != "lo" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,202 @@
+package src
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var colorParameters = map[string]int{
+	"black":   30,
+	"red":     31,
+	"green":   32,
+	"yellow":  33,
+	"blue":    34,
+	"purple":  35,
+	"magenta": 35,
+	"cyan":    36,
+	"white":   37,
+	"default": 39,
+}
+
+var attributeParameters = map[string]string{
+	"bold":          "1",
+	"dim":           "2",
+	"italic":        "3",
+	"underline":     "4",
+	"blink":         "5",
+	"reverse":       "7",
+	"strikethrough": "9",
+}
+
+var rgbPattern = regexp.MustCompile(`^rgb\((\d{1,3}),(\d{1,3}),(\d{1,3})\)$`)
+var spacesInParentheses = regexp.MustCompile(`\(\s*([^)]*?)\s*\)`)
+
+// TextStyle holds the SGR parameters of a style string like "bold italic #ff8800 on #202020".
+type TextStyle struct {
+	Attributes []string
+	Foreground string
+	Background string
+}
+
+// ParseStyle reads a style string made of attributes (bold, dim, italic, underline, blink, reverse,
+// strikethrough), a foreground color and a background color after "on". Colors are names, bright_ names,
+// 256-color indexes, #RRGGBB, #RGB, rgb(r, g, b), slots of the theme or references to external palettes.
+func ParseStyle(style string) (TextStyle, error) {
+	var parsed TextStyle
+	style = spacesInParentheses.ReplaceAllStringFunc(style, func(match string) string {
+		return strings.Join(strings.Fields(match), "")
+	})
+
+	tokens := strings.Fields(strings.ToLower(style))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if parameter, exists := attributeParameters[token]; exists {
+			parsed.Attributes = append(parsed.Attributes, parameter)
+			continue
+		}
+		if token == "on" {
+			if i+1 >= len(tokens) {
+				return parsed, fmt.Errorf("missing background color after \"on\"")
+			}
+			i++
+			background, err := colorParameter(tokens[i])
+			if err != nil {
+				return parsed, err
+			}
+			parsed.Background = toBackground(background)
+			continue
+		}
+		foreground, err := colorParameter(token)
+		if err != nil {
+			return parsed, err
+		}
+		parsed.Foreground = foreground
+	}
+	return parsed, nil
+}
+
+func colorParameter(color string) (string, error) {
+	if themeColor, exists := activeTheme[color]; exists {
+		return literalColorParameter(themeColor)
+	}
+	return literalColorParameter(color)
+}
+
+func literalColorParameter(color string) (string, error) {
+	if parameter, exists := colorParameters[color]; exists {
+		return strconv.Itoa(parameter), nil
+	}
+	if parameter, isReference := paletteColorParameter(color); isReference {
+		return parameter, nil
+	}
+	if color == "gray" || color == "grey" {
+		return "90", nil
+	}
+	for _, prefix := range []string{"bright_", "bright-", "bright"} {
+		if name, found := strings.CutPrefix(color, prefix); found {
+			if parameter, exists := colorParameters[name]; exists && name != "default" {
+				return strconv.Itoa(parameter + 60), nil
+			}
+		}
+	}
+	if index, err := strconv.Atoi(color); err == nil {
+		if index < 0 || index > 255 {
+			return "", fmt.Errorf("color index %d is not between 0 and 255", index)
+		}
+		if index < 16 {
+			// The 16 colors of the terminal palette work on every terminal with their own codes
+			return ansiParameter(index, false), nil
+		}
+		return fmt.Sprintf("38;5;%d", index), nil
+	}
+	if strings.HasPrefix(color, "#") {
+		hex := color[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if value, err := strconv.ParseUint(hex, 16, 32); err == nil && len(hex) == 6 {
+			return fmt.Sprintf("38;2;%d;%d;%d", value>>16, value>>8&0xff, value&0xff), nil
+		}
+	}
+	if match := rgbPattern.FindStringSubmatch(color); match != nil {
+		channels := make([]int, 3)
+		for i, channel := range match[1:] {
+			channels[i], _ = strconv.Atoi(channel)
+			if channels[i] > 255 {
+				return "", fmt.Errorf("%s has a channel above 255", color)
+			}
+		}
+		return fmt.Sprintf("38;2;%d;%d;%d", channels[0], channels[1], channels[2]), nil
+	}
+	return "", fmt.Errorf("unknown color or attribute %q", color)
+}
+
+func toBackground(parameter string) string {
+	if rest, found := strings.CutPrefix(parameter, "38;"); found {
+		return "48;" + rest
+	}
+	value, _ := strconv.Atoi(parameter)
+	return strconv.Itoa(value + 10)
+}
+
+// Code is the escape sequence of the style, empty when the style sets nothing.
+func (style TextStyle) Code() string {
+	parameters := append([]string{}, style.Attributes...)
+	if style.Foreground != "" {
+		parameters = append(parameters, style.Foreground)
+	}
+	if style.Background != "" {
+		parameters = append(parameters, style.Background)
+	}
+	if len(parameters) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(parameters, ";") + "m"
+}
+
+type styleField struct {
+	name  string
+	style string
+}
+
+// ValidateStyles makes sure every color of the config is a valid style string. Gradients depend on the theme,
+// see ValidateGradients.
+func ValidateStyles(config Config) error {
+	fields := []styleField{
+		{"header.text_color", config.Header.TextColor},
+		{"header.line_color", config.Header.LineColor},
+		{"footer.text_color", config.Footer.TextColor},
+		{"footer.line_color", config.Footer.LineColor},
+		{"general.border_color", config.General.BorderColor},
+		{"general.inline.separator_color", config.General.Inline.SeparatorColor},
+	}
+	for i, color := range config.Ascii.Colors {
+		fields = append(fields, styleField{fmt.Sprintf("ascii.colors.%d", i), color})
+	}
+	for i, background := range config.General.Inline.Backgrounds {
+		fields = append(fields, styleField{fmt.Sprintf("general.inline.backgrounds.%d", i), background})
+	}
+	for i, item := range config.Items {
+		name := fmt.Sprintf("items.%d", i)
+		if item.Group != nil {
+			fields = append(fields,
+				styleField{name + " group text_color", item.Group.TextColor},
+				styleField{name + " group line_color", item.Group.LineColor})
+		}
+		fields = append(fields,
+			styleField{name + ".text_color", item.TextColor},
+			styleField{name + ".icon_color", item.IconColor})
+		for _, rule := range item.ValueColor {
+			fields = append(fields, styleField{name + ".value_color", rule.Color})
+		}
+	}
+
+	for _, field := range fields {
+		if _, err := ParseStyle(field.style); err != nil {
+			return fmt.Errorf("invalid style %q in %s: %w", field.style, field.name, err)
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,13 @@ import (
 
 const defaultConfigValue = "Not Found"
 
+const httpTimeout = 3 * time.Second
+
+var httpClient = &http.Client{Timeout: httpTimeout}
+
+// sourceHttpClient has no fixed timeout, each request is bounded by the timeout of its source.
+var sourceHttpClient = &http.Client{}
+
 // OSRelease structure
 type OSRelease struct {
 	ANSI_COLOR        string
@@ -48,17 +56,28 @@ type OSRelease struct {
 }
 
 var (
-	ReadFile    = os.ReadFile
-	ExecCommand = exec.Command
-	OpenFile    = os.Open
-	CurrentUser = user.Current
-	Hostname    = os.Hostname
-	LookupEnv   = os.LookupEnv
-	ReadDir     = os.ReadDir
-	ReadAll     = io.ReadAll
-	HttpGet     = http.Get
+	ReadFile     = os.ReadFile
+	ExecCommand  = exec.Command
+	OpenFile     = os.Open
+	CurrentUser  = user.Current
+	Hostname     = os.Hostname
+	LookupEnv    = os.LookupEnv
+	ReadDir      = os.ReadDir
+	ReadAll      = io.ReadAll
+	HttpGet      = httpClient.Get
+	HttpDo       = httpClient.Do
+	HttpSourceDo = sourceHttpClient.Do
 )
 
+// LoadOsRelease reads /etc/os-release, empty when it cannot be read.
+func LoadOsRelease() OSRelease {
+	data, err := ReadFile("/etc/os-release")
+	if err != nil {
+		return OSRelease{}
+	}
+	return GetOsRelease(bytes.NewReader(data))
+}
+
 func GetOsRelease(reader io.Reader) OSRelease {
 	osRelease := OSRelease{}
 	data := make(map[string]string)
@@ -103,22 +122,22 @@ func GetOsRelease(reader io.Reader) OSRelease {
 		}
 	}
 
-	SaveDataToFile(data)
+	SaveValuesToFile(TextValues(data))
 
 	return osRelease
 }
 
 // GetDesktopManager returns the name of the desktop manager
-func GetDesktopManager() string {
+func GetDesktopManager() Value {
 	envVars := []string{
 		"XDG_CURRENT_DESKTOP",
 		"DESKTOP_SESSION",
 		"GDMSESSION",
 		"XDG_SESSION_DESKTOP",
 	}
-	value := GetEnvVar(envVars)
-	if value != defaultConfigValue {
-		SaveDataToFile(map[string]string{"dm": value})
+	value := TextValue(GetEnvVar(envVars))
+	if value.Err == "" {
+		SaveValuesToFile(map[string]Value{"dm": value})
 		return value
 	}
 
@@ -130,8 +149,9 @@ func GetDesktopManager() string {
 	}
 	for file, dm := range systemFiles {
 		if _, err := os.Stat(file); err == nil {
-			SaveDataToFile(map[string]string{"dm": dm})
-			return dm
+			value := TextValue(dm)
+			SaveValuesToFile(map[string]Value{"dm": value})
+			return value
 		}
 	}
 
@@ -141,62 +161,68 @@ func GetDesktopManager() string {
 		"lxsession":     "LXDE",
 		"xfce4-session": "XFCE",
 	}
-	SaveDataToFile(map[string]string{"dm": GetRunningProcess(processes)})
-	return GetRunningProcess(processes)
+	value = TextValue(GetRunningProcess(processes))
+	SaveValuesToFile(map[string]Value{"dm": value})
+	return value
 }
 
-func GetUsername() string {
+func GetUsername() Value {
 	user, err := user.Current()
 	if err != nil {
-		SaveDataToFile(map[string]string{"user": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"user": value})
+		return value
 	}
-	value := strings.TrimRight(string(user.Username), "\x00")
-	SaveDataToFile(map[string]string{"user": value})
+	value := TextValue(strings.TrimRight(string(user.Username), "\x00"))
+	SaveValuesToFile(map[string]Value{"user": value})
 	return value
 }
 
-func GetHostname() string {
+func GetHostname() Value {
 	hostname, err := os.Hostname()
 	if err != nil {
-		SaveDataToFile(map[string]string{"hostname": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"hostname": value})
+		return value
 	}
-	value := strings.TrimRight(string(hostname), "\x00")
-	SaveDataToFile(map[string]string{"hostname": value})
+	value := TextValue(strings.TrimRight(string(hostname), "\x00"))
+	SaveValuesToFile(map[string]Value{"hostname": value})
 	return value
 }
 
-func GetKernelVersion() string {
+func GetKernelVersion() Value {
 	var uname syscall.Utsname
 	if err := syscall.Uname(&uname); err != nil {
-		SaveDataToFile(map[string]string{"kernel": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"kernel": value})
+		return value
 	}
-	value := CharsToString(uname.Release)
-	SaveDataToFile(map[string]string{"kernel": value})
+	value := TextValue(CharsToString(uname.Release))
+	SaveValuesToFile(map[string]Value{"kernel": value})
 	return value
 }
 
-func GetShell() string {
+func GetShell() Value {
 	shell := os.Getenv("SHELL")
 	if shell == "" {
-		SaveDataToFile(map[string]string{"shell": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"shell": value})
+		return value
 	}
-	SaveDataToFile(map[string]string{"shell": shell})
-	return shell
+	value := TextValue(shell)
+	SaveValuesToFile(map[string]Value{"shell": value})
+	return value
 }
 
-func GetTerminal() string {
+func GetTerminal() Value {
 	envVars := []string{
 		"TERM_PROGRAM",
 		"COLORTERM",
 		"TERM",
 	}
-	value := GetEnvVar(envVars)
-	if value != defaultConfigValue {
-		SaveDataToFile(map[string]string{"term": value})
+	value := TextValue(GetEnvVar(envVars))
+	if value.Err == "" {
+		SaveValuesToFile(map[string]Value{"term": value})
 		return value
 	}
 
@@ -215,8 +241,9 @@ func GetTerminal() string {
 		"screen":         "screen",
 		"ghostty":        "Ghostty",
 	}
-	SaveDataToFile(map[string]string{"term": GetRunningProcess(processes)})
-	return GetRunningProcess(processes)
+	value = TextValue(GetRunningProcess(processes))
+	SaveValuesToFile(map[string]Value{"term": value})
+	return value
 }
 
 // Cache the contents of /proc/meminfo to avoid repeated reads
@@ -237,10 +264,12 @@ func readMemInfo() string {
 	return memInfoCache
 }
 
-func GetMotherboardInfo() string {
+func GetMotherboardInfo() Value {
 	data, err := os.ReadFile("/sys/class/dmi/id/board_name")
 	if err != nil {
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"motherboard": value})
+		return value
 	}
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 	var motherboardInfo string
@@ -250,10 +279,14 @@ func GetMotherboardInfo() string {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"motherboard": value})
+		return value
 	}
 
-	return motherboardInfo
+	value := TextValue(motherboardInfo)
+	SaveValuesToFile(map[string]Value{"motherboard": value})
+	return value
 }
 
 func readCPUInfo() string {
@@ -268,7 +301,7 @@ func readCPUInfo() string {
 	return cpuInfoCache
 }
 
-func GetCPUInfo() string {
+func GetCPUInfo() Value {
 	cpuInfo := readCPUInfo()
 	scanner := bufio.NewScanner(strings.NewReader(cpuInfo))
 
@@ -277,21 +310,23 @@ func GetCPUInfo() string {
 		if strings.HasPrefix(line, "model name") {
 			fields := strings.Split(line, ":")
 			if len(fields) > 1 {
-				value := strings.TrimSpace(fields[1])
-				SaveDataToFile(map[string]string{"cpu": value})
+				value := TextValue(strings.TrimSpace(fields[1]))
+				SaveValuesToFile(map[string]Value{"cpu": value})
 				return value
 			}
 		}
 	}
-	SaveDataToFile(map[string]string{"cpu": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"cpu": value})
+	return value
 }
 
-func GetRAMUsage() string {
+func GetRAMUsage() Value {
 	memInfo := readMemInfo()
 	if memInfo == "" {
-		SaveDataToFile(map[string]string{"ram %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"ram %": value})
+		return value
 	}
 
 	scanner := bufio.NewScanner(strings.NewReader(memInfo))
@@ -310,23 +345,25 @@ func GetRAMUsage() string {
 	}
 
 	if memTotal == 0 {
-		SaveDataToFile(map[string]string{"ram %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"ram %": value})
+		return value
 	}
 
 	memUsed := memTotal - memAvailable
 	ramUsage := (float64(memUsed) / float64(memTotal)) * 100.0
 
-	value := fmt.Sprintf("%.2f%%", ramUsage)
-	SaveDataToFile(map[string]string{"ram %": value})
+	value := PercentValue(ramUsage)
+	SaveValuesToFile(map[string]Value{"ram %": value})
 	return value
 }
 
-func GetRAMInfo() string {
+func GetRAMInfo() Value {
 	memInfo := readMemInfo()
 	if memInfo == "" {
-		SaveDataToFile(map[string]string{"ram": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"ram": value})
+		return value
 	}
 
 	scanner := bufio.NewScanner(strings.NewReader(memInfo))
@@ -342,8 +379,9 @@ func GetRAMInfo() string {
 	}
 
 	if memTotal == 0 {
-		SaveDataToFile(map[string]string{"ram": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"ram": value})
+		return value
 	}
 
 	// Convert kB to MB
@@ -358,22 +396,22 @@ func GetRAMInfo() string {
 		}
 	}
 
+	display := fmt.Sprintf("%d MB", closestSize)
 	if closestSize >= 1024 {
-		value := fmt.Sprintf("%d GB", closestSize/1024)
-		SaveDataToFile(map[string]string{"ram": value})
-		return value
+		display = fmt.Sprintf("%d GB", closestSize/1024)
 	}
-	value := fmt.Sprintf("%d MB", closestSize)
-	SaveDataToFile(map[string]string{"ram": value})
+	value := BytesValue(float64(closestSize)*1024*1024, display)
+	SaveValuesToFile(map[string]Value{"ram": value})
 	return value
 }
 
-func GetDriveInfo() string {
+func GetDriveInfo() Value {
 	cmd := ExecCommand("lsblk", "-o", "NAME,SIZE,MOUNTPOINT")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"drive": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"drive": value})
+		return value
 	}
 
 	lines := strings.Split(string(output), "\n")
@@ -382,39 +420,50 @@ func GetDriveInfo() string {
 		if len(fields) >= 3 && fields[2] == "/" {
 			name := strings.TrimPrefix(fields[0], "├─")
 			name = strings.TrimPrefix(name, "└─")
-			value := fmt.Sprintf("%s, %s, %s", name, fields[1], fields[2])
-			SaveDataToFile(map[string]string{"drive": value})
+			value := TextValue(fmt.Sprintf("%s, %s, %s", name, fields[1], fields[2]))
+			SaveValuesToFile(map[string]Value{"drive": value})
 			return value
 		}
 	}
 
-	SaveDataToFile(map[string]string{"drive": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"drive": value})
+	return value
 }
 
-func GetDriveUsage() string {
+func GetDriveUsage() Value {
 	cmd := ExecCommand("df", "-h", "/")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"drive %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"drive %": value})
+		return value
 	}
 
 	lines := strings.Split(string(output), "\n")
 	if len(lines) > 1 {
 		fields := strings.Fields(lines[1])
 		if len(fields) >= 5 {
-			value := fields[4]
-			SaveDataToFile(map[string]string{"drive %": value})
+			value := usageValue(fields[4])
+			SaveValuesToFile(map[string]Value{"drive %": value})
 			return value
 		}
 	}
 
-	SaveDataToFile(map[string]string{"drive %": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"drive %": value})
+	return value
 }
 
-func GetCPUUsage() string {
+func usageValue(text string) Value {
+	value := ParseValue(text)
+	if value.Numeric {
+		value.Unit = "%"
+	}
+	return value
+}
+
+func GetCPUUsage() Value {
 	idle0, total0 := GetCPUSample()
 	time.Sleep(1 * time.Second)
 	idle1, total1 := GetCPUSample()
@@ -424,8 +473,8 @@ func GetCPUUsage() string {
 
 	cpuUsage := (1.0 - idleTicks/totalTicks) * 100.0
 
-	value := fmt.Sprintf("%.2f%%", cpuUsage)
-	SaveDataToFile(map[string]string{"cpu %": value})
+	value := PercentValue(cpuUsage)
+	SaveValuesToFile(map[string]Value{"cpu %": value})
 	return value
 }
 
@@ -454,7 +503,7 @@ func GetCPUSample() (uint64, uint64) {
 	return 0, 0
 }
 
-func GetGPUInfo() string {
+func GetGPUInfo() Value {
 	if IsCommandAvailable("nvidia-smi") {
 		return GetNvidiaGPUInfo()
 	} else if IsCommandAvailable("rocm-smi") {
@@ -462,11 +511,12 @@ func GetGPUInfo() string {
 	} else if IsCommandAvailable("intel_gpu_top") {
 		return GetIntelGPUInfo()
 	}
-	SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"gpu": value})
+	return value
 }
 
-func GetGPUUsage() string {
+func GetGPUUsage() Value {
 	if IsCommandAvailable("nvidia-smi") {
 		return GetNvidiaGPUUsage()
 	} else if IsCommandAvailable("rocm-smi") {
@@ -474,141 +524,153 @@ func GetGPUUsage() string {
 	} else if IsCommandAvailable("intel_gpu_top") {
 		return GetIntelGPUUsage()
 	}
-	SaveDataToFile(map[string]string{"gpu %": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"gpu %": value})
+	return value
 }
 
-func GetNvidiaGPUInfo() string {
+func GetNvidiaGPUInfo() Value {
 	cmd := ExecCommand("nvidia-smi", "--query-gpu=name", "--format=csv,noheader")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu": value})
+		return value
 	}
-	value := strings.TrimSpace(string(output))
-	SaveDataToFile(map[string]string{"gpu": value})
+	value := TextValue(strings.TrimSpace(string(output)))
+	SaveValuesToFile(map[string]Value{"gpu": value})
 	return value
 }
 
-func GetNvidiaGPUUsage() string {
+func GetNvidiaGPUUsage() Value {
 	cmd := ExecCommand("nvidia-smi", "--query-gpu=utilization.gpu", "--format=csv,noheader,nounits")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu %": value})
+		return value
 	}
-	value := strings.TrimSpace(string(output)) + "%"
-	SaveDataToFile(map[string]string{"gpu %": value})
+	value := usageValue(strings.TrimSpace(string(output)) + "%")
+	SaveValuesToFile(map[string]Value{"gpu %": value})
 	return value
 }
 
-func GetAmdGPUInfo() string {
+func GetAmdGPUInfo() Value {
 	cmd := ExecCommand("rocm-smi", "--showproductname")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu": value})
+		return value
 	}
 	lines := strings.Split(string(output), "\n")
 	if len(lines) > 1 {
-		value := strings.TrimSpace(lines[1])
-		SaveDataToFile(map[string]string{"gpu": value})
+		value := TextValue(strings.TrimSpace(lines[1]))
+		SaveValuesToFile(map[string]Value{"gpu": value})
 		return value
 	}
-	SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"gpu": value})
+	return value
 }
 
-func GetAmdGPUUsage() string {
+func GetAmdGPUUsage() Value {
 	cmd := ExecCommand("rocm-smi", "--showuse")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu %": value})
+		return value
 	}
 	lines := strings.Split(string(output), "\n")
 	for _, line := range lines {
 		if strings.Contains(line, "GPU use") {
 			fields := strings.Fields(line)
 			if len(fields) > 2 {
-				value := fields[2]
-				SaveDataToFile(map[string]string{"gpu %": value})
+				value := usageValue(fields[2])
+				SaveValuesToFile(map[string]Value{"gpu %": value})
 				return value
 			}
 		}
 	}
-	SaveDataToFile(map[string]string{"gpu %": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"gpu %": value})
+	return value
 }
 
-func GetIntelGPUInfo() string {
+func GetIntelGPUInfo() Value {
 	cmd := ExecCommand("lspci", "-nn", "-d", "8086:")
 	output, err := cmd.Output()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu": value})
+		return value
 	}
 	lines := strings.Split(string(output), "\n")
 	for _, line := range lines {
 		if strings.Contains(line, "VGA compatible controller") {
-			value := strings.TrimSpace(line)
-			SaveDataToFile(map[string]string{"gpu": value})
+			value := TextValue(strings.TrimSpace(line))
+			SaveValuesToFile(map[string]Value{"gpu": value})
 			return value
 		}
 	}
 
-	SaveDataToFile(map[string]string{"gpu": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"gpu": value})
+	return value
 }
 
-func GetIntelGPUUsage() string {
+func GetIntelGPUUsage() Value {
 	cmd := ExecCommand("sh", "-c", "timeout 1s intel_gpu_top -o - | grep 'Render/3D' | awk '{print $2}'")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
 	if err != nil {
-		SaveDataToFile(map[string]string{"gpu %": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"gpu %": value})
+		return value
 	}
-	value := strings.TrimSpace(out.String())
-	SaveDataToFile(map[string]string{"gpu %": value})
+	value := usageValue(strings.TrimSpace(out.String()))
+	SaveValuesToFile(map[string]Value{"gpu %": value})
 	return value
 }
 
-func GetUptime() string {
+func GetUptime() Value {
 	cmd := ExecCommand("uptime")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
 	if err != nil {
-		SaveDataToFile(map[string]string{"uptime": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"uptime": value})
+		return value
 	}
 
 	uptimeOutput := out.String()
 	parts := strings.Split(uptimeOutput, "up ")
 	if len(parts) < 2 {
-		SaveDataToFile(map[string]string{"uptime": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"uptime": value})
+		return value
 	}
 
 	uptimePart := strings.Split(parts[1], ",")[0]
 
-	value := strings.TrimSpace(uptimePart)
+	value := TextValue(strings.TrimSpace(uptimePart))
 
-	SaveDataToFile(map[string]string{"uptime": value})
+	SaveValuesToFile(map[string]Value{"uptime": value})
 	return value
 }
 
-func GetWM() string {
+func GetWM() Value {
 	envVars := []string{
 		"XDG_SESSION_DESKTOP",
 		"XDG_CURRENT_DESKTOP",
 		"DESKTOP_SESSION",
 	}
-	value := GetEnvVar(envVars)
-	if value != defaultConfigValue {
-		SaveDataToFile(map[string]string{"wm": value})
+	value := TextValue(GetEnvVar(envVars))
+	if value.Err == "" {
+		SaveValuesToFile(map[string]Value{"wm": value})
 		return value
 	}
 
@@ -625,18 +687,20 @@ func GetWM() string {
 		"compiz":       "Compiz",
 	}
 
-	SaveDataToFile(map[string]string{"wm": GetRunningProcess(processes)})
-	return GetRunningProcess(processes)
+	value = TextValue(GetRunningProcess(processes))
+	SaveValuesToFile(map[string]Value{"wm": value})
+	return value
 }
 
-func GetResolution() string {
+func GetResolution() Value {
 	cmd := exec.Command("xrandr")
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
 	if err != nil {
-		SaveDataToFile(map[string]string{"resolution": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"resolution": value})
+		return value
 	}
 
 	lines := strings.Split(out.String(), "\n")
@@ -646,45 +710,50 @@ func GetResolution() string {
 			for i, field := range fields {
 				if field == "current" && i+2 < len(fields) {
 					resolution := fields[i+1] + "x" + fields[i+3]
-					value := strings.TrimSuffix(resolution, ",")
-					SaveDataToFile(map[string]string{"resolution": value})
+					value := TextValue(strings.TrimSuffix(resolution, ","))
+					SaveValuesToFile(map[string]Value{"resolution": value})
 					return value
 				}
 			}
 		}
 	}
 
-	SaveDataToFile(map[string]string{"resolution": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"resolution": value})
+	return value
 }
 
-func GetIP() string {
+func GetIP() Value {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
-		SaveDataToFile(map[string]string{"ip": defaultConfigValue})
-		return defaultConfigValue
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"ip": value})
+		return value
 	}
 
 	for _, addr := range addrs {
 		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
 			if ipnet.IP.To4() != nil {
-				SaveDataToFile(map[string]string{"ip": string(ipnet.IP.String())})
-				return ipnet.IP.String()
+				value := TextValue(ipnet.IP.String())
+				SaveValuesToFile(map[string]Value{"ip": value})
+				return value
 			}
 		}
 	}
 
-	SaveDataToFile(map[string]string{"ip": defaultConfigValue})
-	return defaultConfigValue
+	value := TextValue(defaultConfigValue)
+	SaveValuesToFile(map[string]Value{"ip": value})
+	return value
 }
 
-func GetRunningProcessesCount() string {
+func GetRunningProcessesCount() Value {
 	procDir := "/proc"
 	entries, err := os.ReadDir(procDir)
 	if err != nil {
 		fmt.Println("Error reading /proc directory:", err)
-		SaveDataToFile(map[string]string{"processes": "0"})
-		return "0"
+		value := CountValue(0)
+		SaveValuesToFile(map[string]Value{"processes": value})
+		return value
 	}
 
 	count := 0
@@ -696,32 +765,54 @@ func GetRunningProcessesCount() string {
 		}
 	}
 
-	value := fmt.Sprintf("%d", count)
-	SaveDataToFile(map[string]string{"processes": value})
+	value := CountValue(count)
+	SaveValuesToFile(map[string]Value{"processes": value})
 	return value
 }
 
-func GetPublicIP() string {
-	resp, err := HttpGet("https://api.ipify.org?format=text")
-	if err != nil {
-		SaveDataToFile(map[string]string{"public ip": defaultConfigValue})
-		return defaultConfigValue
-	}
-	defer resp.Body.Close()
+// IconKey is the key under which a keyword stores the icon for its item.
+func IconKey(keyword string) string {
+	return keyword + " icon"
+}
 
-	ip, err := ReadAll(resp.Body)
-	if err != nil {
-		SaveDataToFile(map[string]string{"public ip": defaultConfigValue})
-		return defaultConfigValue
+// ItemKeywords lists the keywords needed by the items, including the ones only referenced by templates.
+func ItemKeywords(configItems []ConfigItem) []string {
+	keywords := []string{}
+	seen := make(map[string]bool)
+	for _, item := range configItems {
+		candidates := []string{}
+		// Items with their own github repo or user are fetched on their own
+		if !item.HasGitHubTarget() {
+			candidates = append(candidates, item.Keyword)
+		}
+		candidates = append(candidates, TemplateKeywords(item.Text)...)
+		candidates = append(candidates, TemplateKeywords(item.Value)...)
+		for _, keyword := range candidates {
+			if keyword != "" && !seen[keyword] {
+				seen[keyword] = true
+				keywords = append(keywords, keyword)
+			}
+		}
 	}
+	return keywords
+}
 
-	SaveDataToFile(map[string]string{"public ip": string(ip)})
-	return string(ip)
+// ConfigKeywords lists the keywords needed by the items and by the header and footer templates.
+func ConfigKeywords(config Config) []string {
+	keywords := ItemKeywords(config.Items)
+	for _, text := range []string{config.Header.Text, config.Footer.Text} {
+		for _, keyword := range TemplateKeywords(text) {
+			if !slices.Contains(keywords, keyword) {
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+	return keywords
 }
 
-func MenuItems(config Config, usedatafile bool) map[string]string {
+func MenuItems(config Config, usedatafile bool) map[string]Value {
 
-	items := make(map[string]string)
+	items := make(map[string]Value)
 	var wg sync.WaitGroup
 	mu := &sync.Mutex{}
 
@@ -749,29 +840,29 @@ func MenuItems(config Config, usedatafile bool) map[string]string {
 
 		osRelease := GetOsRelease(osReleaseFile)
 
-		valueMap := map[string]func() string{
-			"os_ansi_color":        func() string { return osRelease.ANSI_COLOR },
-			"os_pretty_name":       func() string { return osRelease.PRETTY_NAME },
-			"os_bug_report_url":    func() string { return osRelease.BUG_REPORT_URL },
-			"os_build_id":          func() string { return osRelease.BUILD_ID },
-			"os_cpe_name":          func() string { return osRelease.CPE_NAME },
-			"os_default_hostname":  func() string { return osRelease.DEFAULT_HOSTNAME },
-			"os_documentation_url": func() string { return osRelease.DOCUMENTATION_URL },
-			"os_home_url":          func() string { return osRelease.HOME_URL },
-			"os_id":                func() string { return osRelease.ID },
-			"os_id_like":           func() string { return osRelease.ID_LIKE },
-			"os_image_id":          func() string { return osRelease.IMAGE_ID },
-			"os_image_version":     func() string { return osRelease.IMAGE_VERSION },
-			"os_version":           func() string { return osRelease.VERSION },
-			"os_logo":              func() string { return osRelease.LOGO },
-			"os_name":              func() string { return osRelease.NAME },
-			"os_support_url":       func() string { return osRelease.SUPPORT_URL },
-			"os_variant":           func() string { return osRelease.VARIANT },
-			"os_variant_id":        func() string { return osRelease.VARIANT_ID },
-			"os_vendor_name":       func() string { return osRelease.VENDOR_NAME },
-			"os_vendor_url":        func() string { return osRelease.VENDOR_URL },
-			"os_version_codename":  func() string { return osRelease.VERSION_CODENAME },
-			"os_version_id":        func() string { return osRelease.VERSION_ID },
+		valueMap := map[string]func() Value{
+			"os_ansi_color":        func() Value { return TextValue(osRelease.ANSI_COLOR) },
+			"os_pretty_name":       func() Value { return TextValue(osRelease.PRETTY_NAME) },
+			"os_bug_report_url":    func() Value { return TextValue(osRelease.BUG_REPORT_URL) },
+			"os_build_id":          func() Value { return TextValue(osRelease.BUILD_ID) },
+			"os_cpe_name":          func() Value { return TextValue(osRelease.CPE_NAME) },
+			"os_default_hostname":  func() Value { return TextValue(osRelease.DEFAULT_HOSTNAME) },
+			"os_documentation_url": func() Value { return TextValue(osRelease.DOCUMENTATION_URL) },
+			"os_home_url":          func() Value { return TextValue(osRelease.HOME_URL) },
+			"os_id":                func() Value { return TextValue(osRelease.ID) },
+			"os_id_like":           func() Value { return TextValue(osRelease.ID_LIKE) },
+			"os_image_id":          func() Value { return TextValue(osRelease.IMAGE_ID) },
+			"os_image_version":     func() Value { return TextValue(osRelease.IMAGE_VERSION) },
+			"os_version":           func() Value { return TextValue(osRelease.VERSION) },
+			"os_logo":              func() Value { return TextValue(osRelease.LOGO) },
+			"os_name":              func() Value { return TextValue(osRelease.NAME) },
+			"os_support_url":       func() Value { return TextValue(osRelease.SUPPORT_URL) },
+			"os_variant":           func() Value { return TextValue(osRelease.VARIANT) },
+			"os_variant_id":        func() Value { return TextValue(osRelease.VARIANT_ID) },
+			"os_vendor_name":       func() Value { return TextValue(osRelease.VENDOR_NAME) },
+			"os_vendor_url":        func() Value { return TextValue(osRelease.VENDOR_URL) },
+			"os_version_codename":  func() Value { return TextValue(osRelease.VERSION_CODENAME) },
+			"os_version_id":        func() Value { return TextValue(osRelease.VERSION_ID) },
 			"user":                 GetUsername,
 			"hostname":             GetHostname,
 			"kernel":               GetKernelVersion,
@@ -781,34 +872,83 @@ func MenuItems(config Config, usedatafile bool) map[string]string {
 			"motherboard":          GetMotherboardInfo,
 			"gpu":                  GetGPUInfo,
 			"cpu":                  GetCPUInfo,
-			"ram":                  GetRAMInfo,
 			"drive":                GetDriveInfo,
+			"term":                 GetTerminal,
+			"wm":                   GetWM,
+			"ip":                   GetIP,
+			"public ip":            func() Value { return GetPublicIP(config.PublicIP) },
+			"weather":              func() Value { return GetWeather(config.Weather) },
+			"forecast":             func() Value { return GetForecast(config.Weather) },
+			"resolution":           GetResolution,
+			"ram":                  GetRAMInfo,
 			"gpu %":                GetGPUUsage,
 			"cpu %":                GetCPUUsage,
 			"ram %":                GetRAMUsage,
 			"drive %":              GetDriveUsage,
-			"term":                 GetTerminal,
 			"processes":            GetRunningProcessesCount,
-			"wm":                   GetWM,
-			"ip":                   GetIP,
-			"public ip":            GetPublicIP,
-			"resolution":           GetResolution,
+			"temperature":          func() Value { return GetTemperature(config.Weather) },
+			"github_stars":         func() Value { return GetGitHubStars(config.GitHub) },
+			"github_forks":         func() Value { return GetGitHubForks(config.GitHub) },
+			"github_issues":        func() Value { return GetGitHubIssues(config.GitHub) },
+			"github_prs":           func() Value { return GetGitHubPRs(config.GitHub) },
+			"github_followers":     func() Value { return GetGitHubFollowers(config.GitHub) },
+		}
+
+		// Keywords that can also provide the icon of their item
+		iconMap := map[string]func() string{
+			"weather":     func() string { return GetWeatherIcon(config.Weather) },
+			"temperature": func() string { return GetWeatherIcon(config.Weather) },
+			"forecast":    func() string { return GetWeatherIcon(config.Weather) },
 		}
 
 		for _, item := range config.Items {
+			if item.HTTP == nil && item.File == "" && !item.HasGitHubTarget() {
+				continue
+			}
 			wg.Add(1)
 			go func(item ConfigItem) {
 				defer wg.Done()
-				if valueFunc, exists := valueMap[item.Keyword]; exists {
+				var value Value
+				if item.HTTP != nil {
+					value = GetHTTPValue(*item.HTTP)
+				} else if item.HasGitHubTarget() {
+					value = GetGitHubItemValue(item, config.GitHub)
+				} else {
+					value = GetFileValue(item)
+				}
+				mu.Lock()
+				items[item.Key()] = value
+				mu.Unlock()
+			}(item)
+		}
+
+		for _, keyword := range ConfigKeywords(config) {
+			wg.Add(1)
+			go func(keyword string) {
+				defer wg.Done()
+				if valueFunc, exists := valueMap[keyword]; exists {
 					value := valueFunc()
 					mu.Lock()
-					items[item.Keyword] = value
+					items[keyword] = value
 					mu.Unlock()
 				}
-			}(item)
+				if iconFunc, exists := iconMap[keyword]; exists {
+					icon := TextValue(iconFunc())
+					SaveValuesToFile(map[string]Value{IconKey(keyword): icon})
+					mu.Lock()
+					items[IconKey(keyword)] = icon
+					mu.Unlock()
+				}
+			}(keyword)
 		}
 		wg.Wait()
 	}
 
+	if usedatafile {
+		attachHistory(items, LoadHistory())
+	} else {
+		attachHistory(items, RecordHistory(items, config.History))
+	}
+
 	return items
 }
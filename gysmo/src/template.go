@@ -0,0 +1,197 @@
+package src
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Keywords with spaces can't be written as {{.ram %}}, so {{."ram %"}} is accepted and
+// rewritten to {{(index . "ram %")}} before parsing.
+var quotedFieldPattern = regexp.MustCompile(`(^|[\s{(|])\.("(?:[^"\\]|\\.)*")`)
+
+var templateFuncs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"truncate": truncateText,
+	"default":  defaultText,
+	"humanize": humanizeBytes,
+}
+
+func IsTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	text = quotedFieldPattern.ReplaceAllString(text, "$1(index . $2)")
+	return template.New("item").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// RenderTemplate executes text with the collected values. Text that fails to render is returned as is.
+func RenderTemplate(text string, items map[string]string) string {
+	if !IsTemplate(text) {
+		return text
+	}
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return text
+	}
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, items); err != nil {
+		return text
+	}
+	return builder.String()
+}
+
+// TemplateKeywords lists the keywords referenced by a template, either as {{.user}} or {{index . "ram %"}}.
+func TemplateKeywords(text string) []string {
+	if !IsTemplate(text) {
+		return nil
+	}
+	tmpl, err := parseTemplate(text)
+	if err != nil || tmpl.Tree == nil {
+		return nil
+	}
+	keywords := []string{}
+	walkTemplateNode(tmpl.Tree.Root, &keywords)
+	return keywords
+}
+
+func walkTemplateNode(node parse.Node, keywords *[]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkTemplateNode(child, keywords)
+		}
+	case *parse.ActionNode:
+		walkTemplateNode(n.Pipe, keywords)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkTemplateNode(cmd, keywords)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) == 3 {
+			if ident, ok := n.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "index" {
+				if isTemplateRoot(n.Args[1]) {
+					if key, ok := n.Args[2].(*parse.StringNode); ok {
+						*keywords = append(*keywords, key.Text)
+						return
+					}
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			walkTemplateNode(arg, keywords)
+		}
+	case *parse.FieldNode:
+		*keywords = append(*keywords, n.Ident[0])
+	case *parse.VariableNode:
+		// {{$.user}} reaches the values from inside range and with
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			*keywords = append(*keywords, n.Ident[1])
+		}
+	case *parse.ChainNode:
+		walkTemplateNode(n.Node, keywords)
+	case *parse.IfNode:
+		walkTemplateNode(n.Pipe, keywords)
+		walkTemplateNode(n.List, keywords)
+		walkTemplateNode(n.ElseList, keywords)
+	case *parse.RangeNode:
+		walkTemplateNode(n.Pipe, keywords)
+		walkTemplateNode(n.List, keywords)
+		walkTemplateNode(n.ElseList, keywords)
+	case *parse.WithNode:
+		walkTemplateNode(n.Pipe, keywords)
+		walkTemplateNode(n.List, keywords)
+		walkTemplateNode(n.ElseList, keywords)
+	}
+}
+
+func isTemplateRoot(node parse.Node) bool {
+	if _, ok := node.(*parse.DotNode); ok {
+		return true
+	}
+	variable, ok := node.(*parse.VariableNode)
+	return ok && len(variable.Ident) == 1 && variable.Ident[0] == "$"
+}
+
+// ValidateTemplates makes sure every templated text and value of the config can be parsed.
+func ValidateTemplates(config Config) error {
+	for i, item := range config.Items {
+		for _, text := range []string{item.Text, item.Value} {
+			if !IsTemplate(text) {
+				continue
+			}
+			if _, err := parseTemplate(text); err != nil {
+				return fmt.Errorf("invalid template in item %d: %w", i, err)
+			}
+		}
+	}
+	sections := []struct{ name, text string }{{"header", config.Header.Text}, {"footer", config.Footer.Text}}
+	for _, section := range sections {
+		if !IsTemplate(section.text) {
+			continue
+		}
+		if _, err := parseTemplate(section.text); err != nil {
+			return fmt.Errorf("invalid template in %s: %w", section.name, err)
+		}
+	}
+	return nil
+}
+
+func applyTemplates(config Config, items map[string]string) Config {
+	resolved := make([]ConfigItem, len(config.Items))
+	for i, item := range config.Items {
+		item.Text = RenderTemplate(item.Text, items)
+		item.Value = RenderTemplate(item.Value, items)
+		resolved[i] = item
+	}
+	config.Items = resolved
+	config.Header.Text = RenderTemplate(config.Header.Text, items)
+	config.Footer.Text = RenderTemplate(config.Footer.Text, items)
+	return config
+}
+
+func truncateText(length int, text string) string {
+	runes := []rune(text)
+	if length <= 0 || len(runes) <= length {
+		return text
+	}
+	if length == 1 {
+		return "…"
+	}
+	return string(runes[:length-1]) + "…"
+}
+
+func defaultText(fallback string, text string) string {
+	if text == "" || text == defaultConfigValue {
+		return fallback
+	}
+	return text
+}
+
+func humanizeBytes(text string) string {
+	size, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return text
+	}
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", size, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", size, units[unit])
+}
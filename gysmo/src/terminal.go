@@ -0,0 +1,63 @@
+package src
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	Rows    uint16
+	Columns uint16
+	XPixel  uint16
+	YPixel  uint16
+}
+
+// TerminalSize asks the terminal attached to stdout for its columns and rows, 0 if stdout is not a terminal.
+var TerminalSize = func() (int, int) {
+	var size winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(size.Columns), int(size.Rows)
+}
+
+// StdoutIsTerminal tells whether stdout is a terminal rather than a pipe or a file.
+var StdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalWidth picks the width the menu must fit in: the --width flag, then general.width,
+// then the terminal itself and finally $COLUMNS. 0 means the width is unknown and nothing is cut.
+func TerminalWidth(flagWidth int, configWidth int) int {
+	if flagWidth > 0 {
+		return flagWidth
+	}
+	if configWidth > 0 {
+		return configWidth
+	}
+	if columns, _ := TerminalSize(); columns > 0 {
+		return columns
+	}
+	return envSize("COLUMNS")
+}
+
+// TerminalHeight returns the rows of the terminal, then $LINES, or 0 when unknown.
+func TerminalHeight() int {
+	if _, rows := TerminalSize(); rows > 0 {
+		return rows
+	}
+	return envSize("LINES")
+}
+
+func envSize(name string) int {
+	if value, exists := LookupEnv(name); exists {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			return size
+		}
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Theme maps the palette slots to colors, see ThemeSlots.
+type Theme map[string]string
+
+// ThemeSlots are the names usable as colors in any style string of the config.
+var ThemeSlots = []string{"accent", "muted", "c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8"}
+
+// The default theme uses the colors of the terminal, so configs using slots look the same as before without a theme.
+var defaultTheme = Theme{
+	"accent": "blue",
+	"muted":  "gray",
+	"c1":     "red",
+	"c2":     "green",
+	"c3":     "yellow",
+	"c4":     "blue",
+	"c5":     "purple",
+	"c6":     "cyan",
+	"c7":     "white",
+	"c8":     "bright_black",
+}
+
+var builtinThemes = map[string]Theme{
+	"catppuccin-mocha": {
+		"accent": "#cba6f7",
+		"muted":  "#6c7086",
+		"c1":     "#f38ba8",
+		"c2":     "#a6e3a1",
+		"c3":     "#f9e2af",
+		"c4":     "#89b4fa",
+		"c5":     "#f5c2e7",
+		"c6":     "#94e2d5",
+		"c7":     "#cdd6f4",
+		"c8":     "#585b70",
+	},
+	"gruvbox": {
+		"accent": "#fe8019",
+		"muted":  "#928374",
+		"c1":     "#fb4934",
+		"c2":     "#b8bb26",
+		"c3":     "#fabd2f",
+		"c4":     "#83a598",
+		"c5":     "#d3869b",
+		"c6":     "#8ec07c",
+		"c7":     "#ebdbb2",
+		"c8":     "#665c54",
+	},
+	"nord": {
+		"accent": "#88c0d0",
+		"muted":  "#616e88",
+		"c1":     "#bf616a",
+		"c2":     "#a3be8c",
+		"c3":     "#ebcb8b",
+		"c4":     "#81a1c1",
+		"c5":     "#b48ead",
+		"c6":     "#8fbcbb",
+		"c7":     "#eceff4",
+		"c8":     "#4c566a",
+	},
+	"dracula": {
+		"accent": "#bd93f9",
+		"muted":  "#6272a4",
+		"c1":     "#ff5555",
+		"c2":     "#50fa7b",
+		"c3":     "#f1fa8c",
+		"c4":     "#bd93f9",
+		"c5":     "#ff79c6",
+		"c6":     "#8be9fd",
+		"c7":     "#f8f8f2",
+		"c8":     "#44475a",
+	},
+}
+
+var activeTheme = defaultTheme
+
+// SetTheme makes the slots of the style strings use the colors of theme.
+func SetTheme(theme Theme) {
+	activeTheme = theme
+}
+
+func themePath(name string) string {
+	if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+	return filepath.Join(LoadWorkingPath(), "themes", name)
+}
+
+// LoadTheme returns a built-in theme or reads ~/.config/gysmo/themes/<name>.json. Slots missing from the
+// file keep their default color, an empty name is the default theme.
+func LoadTheme(name string) (Theme, error) {
+	if name == "" {
+		return defaultTheme, nil
+	}
+	if theme, exists := builtinThemes[name]; exists {
+		return theme, nil
+	}
+
+	// A theme file can only come from the themes directory
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") || filepath.Base(name) != name {
+		return nil, fmt.Errorf("theme %q is not a valid name", name)
+	}
+	data, err := ReadFile(themePath(name))
+	if err != nil {
+		return nil, fmt.Errorf("theme %q is not a built-in theme and could not be read: %w", name, err)
+	}
+	var colors Theme
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return nil, fmt.Errorf("invalid theme %q: %w", name, err)
+	}
+
+	theme := Theme{}
+	for slot, color := range defaultTheme {
+		theme[slot] = color
+	}
+	for slot, color := range colors {
+		if !slices.Contains(ThemeSlots, slot) {
+			return nil, fmt.Errorf("unknown slot %q in theme %q, expected one of %s", slot, name, strings.Join(ThemeSlots, ", "))
+		}
+		if _, err := literalColorParameter(strings.ToLower(color)); err != nil {
+			return nil, fmt.Errorf("invalid color for %s in theme %q: %w", slot, name, err)
+		}
+		theme[slot] = strings.ToLower(color)
+	}
+	return theme, nil
+}
@@ -22,32 +22,28 @@ const (
 	White  = "\033[37m"
 )
 
+// GetColorCode returns the escape sequence of a style string, see ParseStyle. Invalid styles reset the color.
 func GetColorCode(color string) string {
-	switch strings.ToLower(color) {
-	case "red":
-		return Red
-	case "green":
-		return Green
-	case "yellow":
-		return Yellow
-	case "blue":
-		return Blue
-	case "purple":
-		return Purple
-	case "cyan":
-		return Cyan
-	case "white":
-		return White
-	case "":
-		return Reset
-	default:
-		// Check if the color is an RGB code in the format "#RRGGBB"
-		if strings.HasPrefix(color, "#") && len(color) == 7 {
-			r, g, b := HexToRGB(color)
-			return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
-		}
+	style, err := ParseStyle(color)
+	if err != nil {
 		return Reset
 	}
+	if code := style.Code(); code != "" {
+		return code
+	}
+	return Reset
+}
+
+// GetBackgroundColorCode uses the color of a style string as background, empty when there is none.
+func GetBackgroundColorCode(color string) string {
+	style, err := ParseStyle(color)
+	if err != nil || (style.Foreground == "" && style.Background == "") {
+		return ""
+	}
+	if style.Background != "" {
+		return "\033[" + style.Background + "m"
+	}
+	return "\033[" + toBackground(style.Foreground) + "m"
 }
 
 func GetEnvVar(envVars []string) string {
@@ -146,12 +142,12 @@ func StripAnsiCodes(str string) string {
 	return re.ReplaceAllString(str, "")
 }
 
-func SaveDataToFile(data map[string]string) error {
+func SaveValuesToFile(data map[string]Value) error {
 	// Read existing data from the file
 	workingPath := LoadWorkingPath()
 	dataPath := filepath.Join(workingPath, "data", "data.json")
 
-	existingData := make(map[string]string)
+	existingData := make(map[string]Value)
 	file, err := os.Open(dataPath)
 	if err == nil {
 		defer file.Close()
@@ -180,39 +176,59 @@ func SaveDataToFile(data map[string]string) error {
 	return nil
 }
 
-func LoadWorkingPath() string {
-	homeDir, err := os.UserHomeDir()
+// maxResponseSize caps the body read from any API, 1 MiB is plenty for the small documents gysmo uses.
+const maxResponseSize = 1 << 20
+
+// ReadResponse reads a response body, failing when it is larger than maxResponseSize.
+func ReadResponse(body io.Reader) ([]byte, error) {
+	data, err := ReadAll(io.LimitReader(body, maxResponseSize+1))
 	if err != nil {
-		fmt.Println("Error getting home directory:", err)
+		return nil, err
 	}
-	workingPath := filepath.Join(homeDir, ".config", "gysmo")
-	return workingPath
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response is larger than %d bytes", maxResponseSize)
+	}
+	return data, nil
+}
+
+// GetJSON fetches url with HttpGet and decodes the JSON body into target.
+func GetJSON(url string, target any) error {
+	resp, err := HttpGet(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status from %s: %s", url, resp.Status)
+	}
+
+	body, err := ReadResponse(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
 }
 
-func IsHeaderOrFooter(line string, config Config, index int, totalLines int) string {
-	if index == 0 && strings.Contains(line, config.Header.Text) {
-		return "Header"
+// ExpandHome replaces a leading ~/ with the home directory of the user.
+func ExpandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
 	}
-	if index != 0 && strings.Contains(line, config.Footer.Text) {
-		return "Footer"
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
 	}
-	return "Content"
+	return filepath.Join(homeDir, path[2:])
 }
 
-// validate if string is a footer or header line
-func IsLine(s string) bool {
-	hasDash := false
-	s = strings.TrimSpace(s)
-	s = StripAnsiCodes(s)
-	for _, char := range s {
-		if char != '─' {
-			return false
-		}
-		if char == '─' {
-			hasDash = true
-		}
+func LoadWorkingPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Println("Error getting home directory:", err)
 	}
-	return hasDash
+	workingPath := filepath.Join(homeDir, ".config", "gysmo")
+	return workingPath
 }
 
 // New functions for copying files and checking their existence
@@ -0,0 +1,175 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Value is what a keyword produces. Numeric values keep their raw number and unit
+// so renderers can re-format them; Display is the default text.
+type Value struct {
+	Display string  `json:"display"`
+	Raw     float64 `json:"raw,omitempty"`
+	Unit    string  `json:"unit,omitempty"`
+	Numeric bool    `json:"numeric,omitempty"`
+	Err     string  `json:"error,omitempty"`
+	// History holds the recorded samples of the keyword, it lives in data/history.json.
+	History []float64 `json:"-"`
+}
+
+// ValueFormat holds the per item "format" options applied at render time.
+type ValueFormat struct {
+	Decimals *int   `json:"decimals"`
+	Units    string `json:"units"`
+	Percent  string `json:"percent"`
+}
+
+func TextValue(text string) Value {
+	if text == defaultConfigValue {
+		return Value{Display: text, Err: "not found"}
+	}
+	return Value{Display: text}
+}
+
+func ErrorValue(err error) Value {
+	return Value{Display: defaultConfigValue, Err: err.Error()}
+}
+
+func PercentValue(percent float64) Value {
+	return Value{Display: fmt.Sprintf("%.2f%%", percent), Raw: percent, Unit: "%", Numeric: true}
+}
+
+func CountValue(count int) Value {
+	return Value{Display: strconv.Itoa(count), Raw: float64(count), Numeric: true}
+}
+
+// BytesValue keeps the byte count while showing display, e.g. the rounded "16 GB" of the ram keyword.
+func BytesValue(bytes float64, display string) Value {
+	return Value{Display: display, Raw: bytes, Unit: "B", Numeric: true}
+}
+
+// ParseValue reads a number out of text like "42", "37%" or "-3.5°C". Anything else stays plain text.
+func ParseValue(text string) Value {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || !strings.ContainsRune("0123456789+-.", rune(trimmed[0])) {
+		return TextValue(text)
+	}
+	end := len(trimmed)
+	for end > 0 {
+		if _, err := strconv.ParseFloat(trimmed[:end], 64); err == nil {
+			break
+		}
+		end--
+	}
+	if end == 0 {
+		return TextValue(text)
+	}
+	unit := strings.TrimSpace(trimmed[end:])
+	if strings.ContainsFunc(unit, unicode.IsDigit) || len([]rune(unit)) > 3 {
+		return TextValue(text)
+	}
+	number, _ := strconv.ParseFloat(trimmed[:end], 64)
+	return Value{Display: text, Raw: number, Unit: unit, Numeric: true}
+}
+
+// UnmarshalJSON also accepts the plain strings of data files written by older versions.
+func (value *Value) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*value = TextValue(text)
+		return nil
+	}
+	type plainValue Value
+	var decoded plainValue
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*value = Value(decoded)
+	return nil
+}
+
+func (value Value) String() string {
+	return value.Display
+}
+
+// Format renders the value with the options of an item. Without options, or for text, Display is used.
+func (value Value) Format(format *ValueFormat) string {
+	if format == nil || !value.Numeric {
+		return value.Display
+	}
+
+	switch value.Unit {
+	case "%":
+		decimals := formatDecimals(format, 2)
+		switch format.Percent {
+		case "fraction":
+			return strconv.FormatFloat(value.Raw/100, 'f', decimals+2, 64)
+		case "none":
+			return strconv.FormatFloat(value.Raw, 'f', decimals, 64)
+		case "space":
+			return strconv.FormatFloat(value.Raw, 'f', decimals, 64) + " %"
+		}
+		return strconv.FormatFloat(value.Raw, 'f', decimals, 64) + "%"
+	case "B":
+		return FormatBytes(value.Raw, format.Units, formatDecimals(format, 1))
+	}
+
+	decimals := 0
+	if value.Raw != math.Trunc(value.Raw) {
+		decimals = 2
+	}
+	number := strconv.FormatFloat(value.Raw, 'f', formatDecimals(format, decimals), 64)
+	if value.Unit != "" && unicode.IsLetter([]rune(value.Unit)[0]) {
+		return number + " " + value.Unit
+	}
+	return number + value.Unit
+}
+
+func formatDecimals(format *ValueFormat, fallback int) int {
+	if format.Decimals != nil && *format.Decimals >= 0 {
+		return *format.Decimals
+	}
+	return fallback
+}
+
+// FormatBytes uses binary units (GiB) unless units is "decimal" (GB).
+func FormatBytes(bytes float64, units string, decimals int) string {
+	base := 1024.0
+	names := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	if units == "decimal" {
+		base = 1000.0
+		names = []string{"B", "kB", "MB", "GB", "TB", "PB"}
+	}
+
+	unit := 0
+	for math.Abs(bytes) >= base && unit < len(names)-1 {
+		bytes /= base
+		unit++
+	}
+	if unit == 0 {
+		decimals = 0
+	}
+	return strconv.FormatFloat(bytes, 'f', decimals, 64) + " " + names[unit]
+}
+
+// DisplayValues flattens values to the text shown by default, as used by templates.
+func DisplayValues(items map[string]Value) map[string]string {
+	display := make(map[string]string, len(items))
+	for key, value := range items {
+		display[key] = value.Display
+	}
+	return display
+}
+
+// TextValues wraps plain strings, e.g. values coming from an external script.
+func TextValues(items map[string]string) map[string]Value {
+	values := make(map[string]Value, len(items))
+	for key, text := range items {
+		values[key] = TextValue(text)
+	}
+	return values
+}
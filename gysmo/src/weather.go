@@ -0,0 +1,408 @@
+package src
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWeatherProvider  = "open-meteo"
+	defaultWeatherCacheTTL  = 15 * time.Minute
+	defaultWeatherForecast  = 3
+	defaultOpenMeteoURL     = "https://api.open-meteo.com"
+	defaultWttrURL          = "https://wttr.in"
+	defaultGeocodingURL     = "https://geocoding-api.open-meteo.com"
+	weatherConditionUnknown = "unknown"
+)
+
+// WeatherDay is one day of a forecast.
+type WeatherDay struct {
+	Date      string  `json:"date"`
+	Condition string  `json:"condition"`
+	Max       float64 `json:"max"`
+	Min       float64 `json:"min"`
+}
+
+// WeatherReport is what every provider returns. Condition is one of the keys of weatherIcons.
+type WeatherReport struct {
+	Condition   string       `json:"condition"`
+	Description string       `json:"description"`
+	Temperature float64      `json:"temperature"`
+	Unit        string       `json:"unit"`
+	Forecast    []WeatherDay `json:"forecast"`
+}
+
+// WeatherProvider fetches the current weather and forecast for the configured location.
+type WeatherProvider interface {
+	Fetch(config WeatherConfig) (WeatherReport, error)
+}
+
+var WeatherProviders = map[string]WeatherProvider{
+	"open-meteo": openMeteoProvider{},
+	"wttr.in":    wttrProvider{},
+}
+
+var weatherIcons = map[string]string{
+	"clear":         "\ue30d",
+	"partly cloudy": "\ue302",
+	"cloudy":        "\ue312",
+	"fog":           "\ue313",
+	"drizzle":       "\ue31b",
+	"rain":          "\ue318",
+	"snow":          "\ue31a",
+	"thunderstorm":  "\ue31d",
+	"unknown":       "\ue374",
+}
+
+// GeocodingURL is the Open-Meteo geocoding API used to find the coordinates of a location.
+var GeocodingURL = defaultGeocodingURL
+
+var weatherMu sync.Mutex
+
+func GetWeather(config WeatherConfig) Value {
+	report, err := loadWeather(config)
+	if err != nil {
+		value := ErrorValue(err)
+		SaveValuesToFile(map[string]Value{"weather": value})
+		return value
+	}
+	value := TextValue(report.Description)
+	SaveValuesToFile(map[string]Value{"weather": value})
+	return value
+}
+
+func GetTemperature(config WeatherConfig) Value {
+	report, err := loadWeather(config)
+	if err != nil {
+		value := ErrorValue(err)
+		SaveValuesToFile(map[string]Value{"temperature": value})
+		return value
+	}
+	value := Value{
+		Display: fmt.Sprintf("%.0f%s", report.Temperature, report.Unit),
+		Raw:     report.Temperature,
+		Unit:    report.Unit,
+		Numeric: true,
+	}
+	SaveValuesToFile(map[string]Value{"temperature": value})
+	return value
+}
+
+func GetForecast(config WeatherConfig) Value {
+	report, err := loadWeather(config)
+	if err != nil || len(report.Forecast) == 0 {
+		value := TextValue(defaultConfigValue)
+		SaveValuesToFile(map[string]Value{"forecast": value})
+		return value
+	}
+
+	days := []string{}
+	for _, day := range report.Forecast {
+		name := day.Date
+		if date, err := time.Parse("2006-01-02", day.Date); err == nil {
+			name = date.Format("Mon")
+		}
+		days = append(days, fmt.Sprintf("%s %s %.0f°/%.0f°", name, WeatherIcon(day.Condition), day.Max, day.Min))
+	}
+	value := TextValue(strings.Join(days, ", "))
+	SaveValuesToFile(map[string]Value{"forecast": value})
+	return value
+}
+
+// GetWeatherIcon returns the icon matching the current condition.
+func GetWeatherIcon(config WeatherConfig) string {
+	report, err := loadWeather(config)
+	if err != nil {
+		return WeatherIcon(weatherConditionUnknown)
+	}
+	return WeatherIcon(report.Condition)
+}
+
+func WeatherIcon(condition string) string {
+	if icon, exists := weatherIcons[condition]; exists {
+		return icon
+	}
+	return weatherIcons[weatherConditionUnknown]
+}
+
+// loadWeather serializes fetches so the weather, temperature and forecast keywords share one request.
+func loadWeather(config WeatherConfig) (WeatherReport, error) {
+	weatherMu.Lock()
+	defer weatherMu.Unlock()
+
+	var report WeatherReport
+	ttl := defaultWeatherCacheTTL
+	if config.CacheTTL != 0 {
+		ttl = time.Duration(config.CacheTTL) * time.Second
+	}
+
+	name := config.Provider
+	if name == "" {
+		name = defaultWeatherProvider
+	}
+	key := weatherCacheKey(name, config)
+
+	if cached, ok := LoadCache(key, ttl); ok {
+		if err := json.Unmarshal([]byte(cached), &report); err == nil {
+			return report, nil
+		}
+	}
+
+	provider, exists := WeatherProviders[name]
+	if !exists {
+		return report, fmt.Errorf("unknown weather provider %q", name)
+	}
+
+	report, err := provider.Fetch(config)
+	if err != nil {
+		return report, err
+	}
+
+	if ttl > 0 {
+		if data, err := json.Marshal(report); err == nil {
+			SaveCache(key, string(data))
+		}
+	}
+	return report, nil
+}
+
+func weatherCacheKey(provider string, config WeatherConfig) string {
+	return fmt.Sprintf("weather %s %s %g,%g %s %d", provider, config.Location, config.Latitude, config.Longitude,
+		config.Units, forecastDays(config))
+}
+
+func weatherUnit(config WeatherConfig) string {
+	if config.Units == "imperial" {
+		return "°F"
+	}
+	return "°C"
+}
+
+func forecastDays(config WeatherConfig) int {
+	if config.ForecastDays > 0 {
+		return config.ForecastDays
+	}
+	return defaultWeatherForecast
+}
+
+type openMeteoProvider struct{}
+
+type openMeteoResponse struct {
+	Current struct {
+		Temperature float64 `json:"temperature_2m"`
+		WeatherCode int     `json:"weather_code"`
+	} `json:"current"`
+	Daily struct {
+		Time        []string  `json:"time"`
+		WeatherCode []int     `json:"weather_code"`
+		Max         []float64 `json:"temperature_2m_max"`
+		Min         []float64 `json:"temperature_2m_min"`
+	} `json:"daily"`
+}
+
+type geocodingResponse struct {
+	Results []struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"results"`
+}
+
+func openMeteoCoordinates(config WeatherConfig) (float64, float64, error) {
+	if config.Latitude != 0 || config.Longitude != 0 {
+		return config.Latitude, config.Longitude, nil
+	}
+	if config.Location == "" {
+		return 0, 0, fmt.Errorf("open-meteo needs a location or latitude and longitude")
+	}
+
+	query := url.Values{}
+	query.Set("name", config.Location)
+	query.Set("count", "1")
+	var response geocodingResponse
+	if err := GetJSON(strings.TrimRight(GeocodingURL, "/")+"/v1/search?"+query.Encode(), &response); err != nil {
+		return 0, 0, err
+	}
+	if len(response.Results) == 0 {
+		return 0, 0, fmt.Errorf("location %q not found", config.Location)
+	}
+	return response.Results[0].Latitude, response.Results[0].Longitude, nil
+}
+
+func (openMeteoProvider) Fetch(config WeatherConfig) (WeatherReport, error) {
+	baseURL := config.URL
+	if baseURL == "" {
+		baseURL = defaultOpenMeteoURL
+	}
+	latitude, longitude, err := openMeteoCoordinates(config)
+	if err != nil {
+		return WeatherReport{}, err
+	}
+
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Set("longitude", strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Set("current", "temperature_2m,weather_code")
+	query.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min")
+	query.Set("forecast_days", strconv.Itoa(forecastDays(config)))
+	query.Set("timezone", "auto")
+	if config.Units == "imperial" {
+		query.Set("temperature_unit", "fahrenheit")
+	}
+
+	var response openMeteoResponse
+	if err := GetJSON(strings.TrimRight(baseURL, "/")+"/v1/forecast?"+query.Encode(), &response); err != nil {
+		return WeatherReport{}, err
+	}
+
+	condition := openMeteoCondition(response.Current.WeatherCode)
+	report := WeatherReport{
+		Condition:   condition,
+		Description: strings.ToUpper(condition[:1]) + condition[1:],
+		Temperature: response.Current.Temperature,
+		Unit:        weatherUnit(config),
+	}
+	daily := response.Daily
+	for i := range daily.Time {
+		if i >= len(daily.WeatherCode) || i >= len(daily.Max) || i >= len(daily.Min) {
+			break
+		}
+		report.Forecast = append(report.Forecast, WeatherDay{
+			Date:      daily.Time[i],
+			Condition: openMeteoCondition(daily.WeatherCode[i]),
+			Max:       daily.Max[i],
+			Min:       daily.Min[i],
+		})
+	}
+	return report, nil
+}
+
+func openMeteoCondition(code int) string {
+	switch {
+	case code == 0 || code == 1:
+		return "clear"
+	case code == 2:
+		return "partly cloudy"
+	case code == 3:
+		return "cloudy"
+	case code == 45 || code == 48:
+		return "fog"
+	case code >= 51 && code <= 57:
+		return "drizzle"
+	case (code >= 61 && code <= 67) || (code >= 80 && code <= 82):
+		return "rain"
+	case (code >= 71 && code <= 77) || code == 85 || code == 86:
+		return "snow"
+	case code >= 95 && code <= 99:
+		return "thunderstorm"
+	}
+	return weatherConditionUnknown
+}
+
+type wttrProvider struct{}
+
+type wttrValue struct {
+	Value string `json:"value"`
+}
+
+type wttrResponse struct {
+	CurrentCondition []struct {
+		TempC       string      `json:"temp_C"`
+		TempF       string      `json:"temp_F"`
+		WeatherCode string      `json:"weatherCode"`
+		WeatherDesc []wttrValue `json:"weatherDesc"`
+	} `json:"current_condition"`
+	Weather []struct {
+		Date     string `json:"date"`
+		MaxTempC string `json:"maxtempC"`
+		MinTempC string `json:"mintempC"`
+		MaxTempF string `json:"maxtempF"`
+		MinTempF string `json:"mintempF"`
+		Hourly   []struct {
+			WeatherCode string `json:"weatherCode"`
+		} `json:"hourly"`
+	} `json:"weather"`
+}
+
+func (wttrProvider) Fetch(config WeatherConfig) (WeatherReport, error) {
+	baseURL := config.URL
+	if baseURL == "" {
+		baseURL = defaultWttrURL
+	}
+
+	location := config.Location
+	if location == "" && (config.Latitude != 0 || config.Longitude != 0) {
+		location = fmt.Sprintf("%g,%g", config.Latitude, config.Longitude)
+	}
+
+	var response wttrResponse
+	if err := GetJSON(strings.TrimRight(baseURL, "/")+"/"+url.PathEscape(location)+"?format=j1", &response); err != nil {
+		return WeatherReport{}, err
+	}
+	if len(response.CurrentCondition) == 0 {
+		return WeatherReport{}, fmt.Errorf("wttr.in returned no current condition")
+	}
+
+	imperial := config.Units == "imperial"
+	current := response.CurrentCondition[0]
+	code, _ := strconv.Atoi(current.WeatherCode)
+	report := WeatherReport{
+		Condition: wttrCondition(code),
+		Unit:      weatherUnit(config),
+	}
+	if imperial {
+		report.Temperature, _ = strconv.ParseFloat(current.TempF, 64)
+	} else {
+		report.Temperature, _ = strconv.ParseFloat(current.TempC, 64)
+	}
+	if len(current.WeatherDesc) > 0 {
+		report.Description = strings.TrimSpace(current.WeatherDesc[0].Value)
+	}
+
+	for i, day := range response.Weather {
+		if i >= forecastDays(config) {
+			break
+		}
+		forecast := WeatherDay{Date: day.Date, Condition: weatherConditionUnknown}
+		if imperial {
+			forecast.Max, _ = strconv.ParseFloat(day.MaxTempF, 64)
+			forecast.Min, _ = strconv.ParseFloat(day.MinTempF, 64)
+		} else {
+			forecast.Max, _ = strconv.ParseFloat(day.MaxTempC, 64)
+			forecast.Min, _ = strconv.ParseFloat(day.MinTempC, 64)
+		}
+		// wttr.in gives 8 three-hour slots, use the one around noon
+		if len(day.Hourly) > 0 {
+			dayCode, _ := strconv.Atoi(day.Hourly[len(day.Hourly)/2].WeatherCode)
+			forecast.Condition = wttrCondition(dayCode)
+		}
+		report.Forecast = append(report.Forecast, forecast)
+	}
+	return report, nil
+}
+
+func wttrCondition(code int) string {
+	switch code {
+	case 113:
+		return "clear"
+	case 116:
+		return "partly cloudy"
+	case 119, 122:
+		return "cloudy"
+	case 143, 248, 260:
+		return "fog"
+	case 185, 263, 266, 281, 284:
+		return "drizzle"
+	case 176, 293, 296, 299, 302, 305, 308, 311, 314, 353, 356, 359:
+		return "rain"
+	case 179, 182, 227, 230, 317, 320, 323, 326, 329, 332, 335, 338, 350, 362, 365, 368, 371, 374, 377:
+		return "snow"
+	case 200, 386, 389, 392, 395:
+		return "thunderstorm"
+	}
+	return weatherConditionUnknown
+}
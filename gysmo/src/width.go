@@ -0,0 +1,40 @@
+package src
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// widthCondition measures terminal cells. Ambiguous characters, including the private
+// use area of Nerd Font icons, are narrow unless general.ambiguous_width says otherwise.
+var widthCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+
+// SetAmbiguousWidth applies the general.ambiguous_width policy: "narrow" (default),
+// "wide" or "locale" to follow the CJK detection of the current locale.
+func SetAmbiguousWidth(policy string) {
+	wide := false
+	switch policy {
+	case "wide":
+		wide = true
+	case "locale":
+		wide = runewidth.EastAsianWidth
+	}
+	widthCondition = &runewidth.Condition{EastAsianWidth: wide, StrictEmojiNeutral: true}
+}
+
+// DisplayWidth returns the number of terminal cells used by s, ignoring ANSI codes.
+// Grapheme clusters are measured as a whole, so combining characters take no extra cell.
+func DisplayWidth(s string) int {
+	return widthCondition.StringWidth(StripAnsiCodes(s))
+}
+
+// PadRight adds spaces after s until it is width cells wide.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-DisplayWidth(s)))
+}
+
+// RepeatToWidth repeats s to fill width cells.
+func RepeatToWidth(s string, width int) string {
+	return strings.Repeat(s, width/max(1, DisplayWidth(s)))
+}
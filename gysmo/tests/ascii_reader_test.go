@@ -29,3 +29,48 @@ func TestReadAsciiArt(t *testing.T) {
 		t.Errorf("Expected ASCII art to contain '_____', but got %s", asciiArt)
 	}
 }
+
+func TestColorAsciiArt(t *testing.T) {
+	colors := src.AsciiColors{"blue", "bold cyan"}
+	art := "${c1}  /\\\n ${c2}/  \\\n/____\\\n$1 done $3"
+
+	expected := src.Blue + "  /\\\n" +
+		src.Blue + " \033[1;36m/  \\\n" +
+		"\033[1;36m/____\\\n" +
+		"\033[1;36m$1 done $3"
+	if colored := src.ColorAsciiArt(art, colors); colored != expected {
+		t.Errorf("Expected %q, but got %q", expected, colored)
+	}
+
+	fastfetch := src.AsciiConfig{Colors: colors, Placeholders: "fastfetch"}
+	expected = src.Blue + "  /\\\n" +
+		src.Blue + " \033[1;36m/  \\\n" +
+		"\033[1;36m/____\\\n" +
+		"\033[1;36m" + src.Blue + " done " + src.Reset
+	if colored := src.PaintAsciiArt(art, fastfetch); colored != expected {
+		t.Errorf("Expected %q, but got %q", expected, colored)
+	}
+
+	if plain := "no placeholder $ here"; src.ColorAsciiArt(plain, colors) != plain {
+		t.Errorf("Expected art without placeholders to be unchanged")
+	}
+}
+
+func TestBuildMenuWithAsciiPlaceholders(t *testing.T) {
+	config := GetConfigWithAscii("left")
+	config.Ascii.Colors = src.AsciiColors{"red", "green"}
+	art := "${c1}####\n${c2}##${c1}##\n####"
+
+	menu := src.BuildBoxMenu(nil, art, config)
+	for _, line := range strings.Split(src.StripAnsiCodes(menu), "\n") {
+		if strings.Contains(line, "$") {
+			t.Errorf("Expected placeholders to be replaced, got %q", line)
+		}
+	}
+	if !strings.Contains(menu, src.Green+"##"+src.Red+"##") {
+		t.Errorf("Expected the colors of the placeholders, got %q", menu)
+	}
+	if !strings.Contains(src.StripAnsiCodes(menu), "  ####      │ Header") || !strings.Contains(src.StripAnsiCodes(menu), "  ####      ├") {
+		t.Errorf("Expected the placeholders to take no room, got:\n%s", src.StripAnsiCodes(menu))
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestRenderBar(t *testing.T) {
+	tests := []struct {
+		percent  float64
+		options  src.BarOptions
+		expected string
+	}{
+		{0, src.BarOptions{}, "░░░░░░░░░░"},
+		{100, src.BarOptions{}, "██████████"},
+		{150, src.BarOptions{}, "██████████"},
+		{50, src.BarOptions{}, "█████░░░░░"},
+		{43.3, src.BarOptions{}, "████▎░░░░░"},
+		{99, src.BarOptions{}, "█████████▉"},
+		{50, src.BarOptions{Width: 4}, "██░░"},
+		{62.5, src.BarOptions{Width: 4, Fill: "#", Empty: "-"}, "##--"},
+		{50, src.BarOptions{Fill: "🟩", Empty: "⬜"}, "🟩🟩⬜⬜⬜"},
+		{50, src.BarOptions{Width: 5, Fill: "██", Empty: "-"}, "██---"},
+		{30, src.BarOptions{Width: 5, Fill: "🟩", Empty: "⬜"}, "⬜⬜ "},
+	}
+
+	for _, test := range tests {
+		result := src.RenderBar(test.percent, test.options)
+		if result != test.expected {
+			t.Errorf("For %.1f%%, expected %s, but got %s", test.percent, test.expected, result)
+		}
+		width := test.options.Width
+		if width == 0 {
+			width = 10
+		}
+		if runewidth.StringWidth(result) != width {
+			t.Errorf("For %.1f%%, expected a bar of %d cells, but got %d", test.percent, width, runewidth.StringWidth(result))
+		}
+	}
+}
+
+func TestBuildMenusWithBar(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{
+		{Text: "RAM", Keyword: "ram %", Display: "bar", Bar: &src.BarOptions{Width: 4}},
+		{Text: "CPU", Keyword: "cpu %", Display: "bar", Bar: &src.BarOptions{Width: 4, Value: "none"}},
+		{Text: "User", Keyword: "user", Display: "bar"},
+	}
+	items := map[string]src.Value{"ram %": src.PercentValue(50), "cpu %": src.PercentValue(100), "user": src.TextValue("grosheth")}
+
+	box := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	for _, expected := range []string{"│ RAM    │ ██░░ 50.00%\n", "│ CPU    │ ████\n", "│ User   │ grosheth\n"} {
+		if !strings.Contains(box, expected) {
+			t.Errorf("Expected the box menu to contain %q, got:\n%s", expected, box)
+		}
+	}
+
+	list := src.StripAnsiCodes(src.BuildListMenu(items, "", config))
+	if !strings.Contains(list, " RAM     ██░░ 50.00%") {
+		t.Errorf("Expected the list menu to contain the bar, got:\n%s", list)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestBuildBoxMenuWithBorderStyles(t *testing.T) {
+	tests := []struct {
+		border string
+		top    string
+		line   string
+		bottom string
+	}{
+		{"", "╭─", "├─", "╰─"},
+		{"rounded", "╭─", "├─", "╰─"},
+		{"sharp", "┌─", "├─", "└─"},
+		{"double", "╔═", "╠═", "╚═"},
+		{"heavy", "┏━", "┣━", "┗━"},
+		{"dashed", "┌┄", "├┄", "└┄"},
+		{"ascii", "+-", "+-", "+-"},
+		{"none", "  ", "  ", "  "},
+	}
+
+	for _, test := range tests {
+		config := GetConfigWithAscii("top")
+		config.Ascii.Enabled = false
+		config.General.MenuPadding = 0
+		config.General.Border = test.border
+
+		for _, policy := range []string{"narrow", "wide"} {
+			setAmbiguousWidth(t, policy)
+			menu := src.StripAnsiCodes(src.BuildBoxMenu(nil, "", config))
+			lines := strings.Split(strings.TrimRight(menu, "\n"), "\n")
+			lines = lines[len(lines)-8:]
+
+			if !strings.HasPrefix(lines[0], test.top) || !strings.HasPrefix(lines[2], test.line) || !strings.HasPrefix(lines[5], test.line) || !strings.HasPrefix(lines[7], test.bottom) {
+				t.Errorf("For %q, expected the border to start with %q, %q and %q, got:\n%s", test.border, test.top, test.line, test.bottom, menu)
+			}
+			if test.border != "none" {
+				assertAligned(t, strings.Join(lines, "\n"))
+			}
+		}
+	}
+}
+
+func TestBuildMenusWithBorderColor(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.General.Border = "sharp"
+	config.General.BorderColor = "blue"
+	config.Footer.LineColor = "red"
+
+	menu := src.BuildBoxMenu(nil, "", config)
+	for _, expected := range []string{src.Blue + "┌", src.Blue + "│" + src.Reset, src.Blue + "├" + src.Reset + src.Blue + "─", src.Red + "─"} {
+		if !strings.Contains(menu, expected) {
+			t.Errorf("Expected %q in the box menu, got %q", expected, menu)
+		}
+	}
+
+	config.Footer.Enabled = false
+	list := src.BuildListMenu(nil, "", config)
+	if !strings.Contains(list, src.Blue+"─") {
+		t.Errorf("Expected the header line of the list to use the border color, got %q", list)
+	}
+
+	config.General.Grid = src.GridConfig{Boxed: true}
+	grid := src.BuildGridMenu(nil, "", config)
+	if !strings.Contains(grid, src.Blue+"┌") || !strings.Contains(grid, src.Blue+"└") {
+		t.Errorf("Expected the boxed grid to use the border style and color, got %q", grid)
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"encoding/json"
+	"gysmo/gysmo/src"
+	"testing"
+)
+
+func getBreakpointConfig(t *testing.T) src.Config {
+	config := GetConfigWithAscii("left")
+	err := json.Unmarshal([]byte(`[
+		{ "max_width": 100, "ascii": { "position": "top", "size": "small" } },
+		{ "max_width": 60, "ascii": { "enabled": false }, "hide": ["shell"] },
+		{ "min_width": 160, "menu_type": "list", "columns": true },
+		{ "max_height": 20, "hide": ["user"] }
+	]`), &config.General.Breakpoints)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal breakpoints: %v", err)
+	}
+	return config
+}
+
+func TestBreakpointMatches(t *testing.T) {
+	tests := []struct {
+		breakpoint src.Breakpoint
+		width      int
+		height     int
+		expected   bool
+	}{
+		{src.Breakpoint{MaxWidth: 80}, 80, 0, true},
+		{src.Breakpoint{MaxWidth: 80}, 81, 0, false},
+		{src.Breakpoint{MinWidth: 80, MaxWidth: 120}, 100, 0, true},
+		{src.Breakpoint{MinWidth: 80}, 0, 0, false},
+		{src.Breakpoint{MaxWidth: 80}, 0, 0, false},
+		{src.Breakpoint{MinHeight: 30}, 100, 40, true},
+		{src.Breakpoint{MaxWidth: 80, MaxHeight: 20}, 60, 30, false},
+		{src.Breakpoint{}, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		if result := test.breakpoint.Matches(test.width, test.height); result != test.expected {
+			t.Errorf("For %+v in %dx%d, expected %v, but got %v", test.breakpoint, test.width, test.height, test.expected, result)
+		}
+	}
+}
+
+func TestApplyBreakpoints(t *testing.T) {
+	config := getBreakpointConfig(t)
+
+	wide := src.ApplyBreakpoints(config, 200, 50)
+	if wide.Ascii.Position != "left" || wide.General.MenuType != "list" || !wide.General.Columns || len(wide.Items) != 2 {
+		t.Errorf("Expected list columns on a wide terminal, got %+v %+v", wide.Ascii, wide.General)
+	}
+
+	medium := src.ApplyBreakpoints(config, 90, 50)
+	if medium.Ascii.Position != "top" || medium.Ascii.Size != "small" || !medium.Ascii.Enabled || medium.General.MenuType != "box" {
+		t.Errorf("Expected the ASCII art on top on a medium terminal, got %+v", medium.Ascii)
+	}
+
+	narrow := src.ApplyBreakpoints(config, 50, 10)
+	if narrow.Ascii.Position != "top" || narrow.Ascii.Enabled {
+		t.Errorf("Expected the ASCII art to be dropped on a narrow terminal, got %+v", narrow.Ascii)
+	}
+	if len(narrow.Items) != 0 {
+		t.Errorf("Expected every hidden item to be removed, got %+v", narrow.Items)
+	}
+
+	unknown := src.ApplyBreakpoints(config, 0, 0)
+	if unknown.Ascii.Position != "left" || len(unknown.Items) != 2 {
+		t.Errorf("Expected nothing to change when the size is unknown, got %+v", unknown.Ascii)
+	}
+	if len(config.Items) != 2 || config.Ascii.Position != "left" {
+		t.Errorf("Expected the original config to be left untouched, got %+v", config.Ascii)
+	}
+}
+
+func TestTerminalHeight(t *testing.T) {
+	originalSize := src.TerminalSize
+	originalLookupEnv := src.LookupEnv
+	defer func() {
+		src.TerminalSize = originalSize
+		src.LookupEnv = originalLookupEnv
+	}()
+
+	rows := 0
+	src.TerminalSize = func() (int, int) { return 0, rows }
+	src.LookupEnv = func(key string) (string, bool) {
+		if key == "LINES" {
+			return "24", true
+		}
+		return "", false
+	}
+
+	if height := src.TerminalHeight(); height != 24 {
+		t.Errorf("Expected $LINES without a terminal, but got %d", height)
+	}
+	rows = 50
+	if height := src.TerminalHeight(); height != 50 {
+		t.Errorf("Expected the terminal height, but got %d", height)
+	}
+}
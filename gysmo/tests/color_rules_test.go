@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"encoding/json"
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestColorRules(t *testing.T) {
+	var rules src.ColorRules
+	data := `[{"above": 90, "color": "red"}, {"above": 70, "color": "yellow"}, {"below": 10, "color": "blue"}, {"color": "green"}]`
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		t.Fatalf("Expected rules to decode, got %v", err)
+	}
+
+	tests := []struct {
+		value    src.Value
+		expected string
+	}{
+		{src.PercentValue(95), "red"},
+		{src.PercentValue(90), "yellow"},
+		{src.PercentValue(71.5), "yellow"},
+		{src.PercentValue(42), "green"},
+		{src.PercentValue(5), "blue"},
+		{src.ParseValue("-3°C"), "blue"},
+		{src.TextValue("Not Found"), "green"},
+	}
+
+	for _, test := range tests {
+		if result := rules.Color(test.value); result != test.expected {
+			t.Errorf("For %s, expected %s, but got %s", test.value.Display, test.expected, result)
+		}
+	}
+}
+
+func TestColorRulesFromString(t *testing.T) {
+	var item src.ConfigItem
+	if err := json.Unmarshal([]byte(`{"text": "User", "keyword": "user", "value_color": "purple"}`), &item); err != nil {
+		t.Fatalf("Expected a plain color to decode, got %v", err)
+	}
+	if result := item.ValueColor.Color(src.TextValue("grosheth")); result != "purple" {
+		t.Errorf("Expected purple, but got %s", result)
+	}
+}
+
+func TestBuildMenusWithColorRules(t *testing.T) {
+	above := 90.0
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{
+		{Text: "RAM", Keyword: "ram %", ValueColor: src.ColorRules{{Above: &above, Color: "red"}, {Color: "green"}}},
+		{Text: "CPU", Keyword: "cpu %", ValueColor: src.ColorRules{{Above: &above, Color: "red"}, {Color: "green"}}},
+	}
+	items := map[string]src.Value{"ram %": src.PercentValue(95), "cpu %": src.PercentValue(12)}
+
+	for name, menu := range map[string]string{
+		"box":  src.BuildBoxMenu(items, "", config),
+		"list": src.BuildListMenu(items, "", config),
+	} {
+		if !strings.Contains(menu, src.Red+"95.00%") {
+			t.Errorf("Expected the %s menu to show the RAM in red, got:\n%q", name, menu)
+		}
+		if !strings.Contains(menu, src.Green+"12.00%") {
+			t.Errorf("Expected the %s menu to show the CPU in green, got:\n%q", name, menu)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"testing"
+)
+
+func mockColorEnv(t *testing.T, env map[string]string, terminal bool) {
+	originalLookupEnv := src.LookupEnv
+	originalStdoutIsTerminal := src.StdoutIsTerminal
+	t.Cleanup(func() {
+		src.LookupEnv = originalLookupEnv
+		src.StdoutIsTerminal = originalStdoutIsTerminal
+	})
+	src.LookupEnv = func(name string) (string, bool) {
+		value, exists := env[name]
+		return value, exists
+	}
+	src.StdoutIsTerminal = func() bool { return terminal }
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		env      map[string]string
+		terminal bool
+		expected src.ColorLevel
+	}{
+		{"truecolor terminal", "auto", map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"}, true, src.TrueColor},
+		{"256 color terminal", "auto", map[string]string{"TERM": "xterm-256color"}, true, src.Color256},
+		{"linux console", "auto", map[string]string{"TERM": "linux"}, true, src.Color16},
+		{"dumb terminal", "auto", map[string]string{"TERM": "dumb"}, true, src.NoColor},
+		{"pipe", "auto", map[string]string{"TERM": "xterm-256color"}, false, src.NoColor},
+		{"NO_COLOR", "auto", map[string]string{"NO_COLOR": "1", "TERM": "xterm-256color"}, true, src.NoColor},
+		{"empty NO_COLOR", "auto", map[string]string{"NO_COLOR": "", "TERM": "xterm-256color"}, true, src.Color256},
+		{"CLICOLOR_FORCE", "auto", map[string]string{"CLICOLOR_FORCE": "1", "TERM": "xterm-256color"}, false, src.Color256},
+		{"CLICOLOR_FORCE=0", "auto", map[string]string{"CLICOLOR_FORCE": "0"}, false, src.NoColor},
+		{"always", "always", map[string]string{"NO_COLOR": "1", "COLORTERM": "24bit"}, false, src.TrueColor},
+		{"never", "never", map[string]string{"COLORTERM": "truecolor"}, true, src.NoColor},
+	}
+
+	for _, test := range tests {
+		mockColorEnv(t, test.env, test.terminal)
+		level, err := src.DetectColorLevel(test.mode)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", test.name, err)
+		}
+		if level != test.expected {
+			t.Errorf("%s: expected level %d, got %d", test.name, test.expected, level)
+		}
+	}
+
+	if _, err := src.DetectColorLevel("sometimes"); err == nil {
+		t.Errorf("Expected an error for an unknown color mode")
+	}
+}
+
+func TestApplyColorLevel(t *testing.T) {
+	menu := "\033[1;38;2;255;136;0mvalue\033[0m \033[48;2;32;32;32mbg\033[0m \033[38;5;196mred\033[0m \033[34mblue\033[0m"
+
+	tests := []struct {
+		level    src.ColorLevel
+		expected string
+	}{
+		{src.TrueColor, menu},
+		{src.Color256, "\033[1;38;5;208mvalue\033[0m \033[48;5;234mbg\033[0m \033[38;5;196mred\033[0m \033[34mblue\033[0m"},
+		{src.Color16, "\033[1;33mvalue\033[0m \033[40mbg\033[0m \033[91mred\033[0m \033[34mblue\033[0m"},
+		{src.NoColor, "value bg red blue"},
+	}
+
+	for _, test := range tests {
+		result := src.ApplyColorLevel(menu, test.level)
+		if result != test.expected {
+			t.Errorf("For level %d, expected %q, but got %q", test.level, test.expected, result)
+		}
+	}
+}
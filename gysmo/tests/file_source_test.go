@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetFileValue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "backlight", "intel_backlight"), 0755)
+	os.WriteFile(filepath.Join(dir, "backlight", "intel_backlight", "brightness"), []byte("19200\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "status"), []byte("backup: ok\nlast run:   2026-10-18 03:00  \nduration: 42s\n"), 0644)
+
+	tests := []struct {
+		name     string
+		item     src.ConfigItem
+		expected string
+	}{
+		{"glob", src.ConfigItem{File: filepath.Join(dir, "backlight", "*", "brightness")}, "19200"},
+		{"line", src.ConfigItem{File: filepath.Join(dir, "status"), Line: 2}, "last run:   2026-10-18 03:00  "},
+		{"line and trim", src.ConfigItem{File: filepath.Join(dir, "status"), Line: 2, Trim: true}, "last run:   2026-10-18 03:00"},
+		{"last line", src.ConfigItem{File: filepath.Join(dir, "status"), Line: -1}, "duration: 42s"},
+		{"regex group", src.ConfigItem{File: filepath.Join(dir, "status"), Regex: `duration: (\d+s)`}, "42s"},
+		{"regex without group", src.ConfigItem{File: filepath.Join(dir, "status"), Regex: `\d{4}-\d{2}-\d{2}`}, "2026-10-18"},
+		{"line out of range", src.ConfigItem{File: filepath.Join(dir, "status"), Line: 10}, "Not Found"},
+		{"regex without match", src.ConfigItem{File: filepath.Join(dir, "status"), Regex: `error`}, "Not Found"},
+		{"missing file", src.ConfigItem{File: filepath.Join(dir, "missing")}, "Not Found"},
+	}
+
+	for _, test := range tests {
+		if result := src.GetFileValue(test.item).Display; result != test.expected {
+			t.Errorf("For %s, expected %q, but got %q", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestFileItemKey(t *testing.T) {
+	first := src.ConfigItem{File: "/etc/status", Line: 1}
+	second := src.ConfigItem{File: "/etc/status", Line: 2}
+	if first.Key() == second.Key() {
+		t.Errorf("Expected items reading different lines to have different keys, got %s", first.Key())
+	}
+}
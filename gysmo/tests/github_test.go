@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"fmt"
+	"gysmo/gysmo/src"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type gitHubStandIn struct {
+	server          *httptest.Server
+	requests        map[string]int
+	tokens          []string
+	remaining       string
+	searchRemaining string
+	retryAfter      string
+}
+
+func setupGitHubTest(t *testing.T) *gitHubStandIn {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "")
+	standIn := &gitHubStandIn{requests: map[string]int{}, remaining: "59", searchRemaining: "9"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/grosheth/gysmo", func(w http.ResponseWriter, r *http.Request) {
+		standIn.requests[r.URL.Path]++
+		standIn.tokens = append(standIn.tokens, r.Header.Get("Authorization"))
+		w.Header().Set("X-RateLimit-Remaining", standIn.remaining)
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"stargazers_count": 42, "forks_count": 7, "open_issues_count": 5}`)
+	})
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		standIn.requests[r.URL.Path]++
+		w.Header().Set("X-RateLimit-Resource", "search")
+		w.Header().Set("X-RateLimit-Remaining", standIn.searchRemaining)
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		if r.URL.Query().Get("q") != "repo:grosheth/gysmo is:pr is:open" {
+			http.Error(w, "bad query", http.StatusUnprocessableEntity)
+			return
+		}
+		fmt.Fprint(w, `{"total_count": 2}`)
+	})
+	mux.HandleFunc("/users/grosheth", func(w http.ResponseWriter, r *http.Request) {
+		standIn.requests[r.URL.Path]++
+		if standIn.retryAfter != "" {
+			w.Header().Set("Retry-After", standIn.retryAfter)
+			http.Error(w, "secondary rate limit", http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"followers": 13}`)
+	})
+	mux.HandleFunc("/repos/octocat/hello-world", func(w http.ResponseWriter, r *http.Request) {
+		standIn.requests[r.URL.Path]++
+		fmt.Fprint(w, `{"stargazers_count": 3, "forks_count": 1, "open_issues_count": 0}`)
+	})
+	mux.HandleFunc("/users/octocat", func(w http.ResponseWriter, r *http.Request) {
+		standIn.requests[r.URL.Path]++
+		fmt.Fprint(w, `{"followers": 99}`)
+	})
+	standIn.server = httptest.NewServer(mux)
+	t.Cleanup(standIn.server.Close)
+
+	originalDo := src.HttpDo
+	src.HttpDo = standIn.server.Client().Do
+	t.Cleanup(func() { src.HttpDo = originalDo })
+	return standIn
+}
+
+func TestGitHubStats(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	t.Setenv("GYSMO_TEST_TOKEN", "secret")
+	config := src.GitHubConfig{Repo: "grosheth/gysmo", TokenEnv: "GYSMO_TEST_TOKEN", APIURL: standIn.server.URL}
+
+	tests := []struct {
+		name     string
+		function func(src.GitHubConfig) src.Value
+		expected string
+	}{
+		{"stars", src.GetGitHubStars, "42"},
+		{"forks", src.GetGitHubForks, "7"},
+		{"issues", src.GetGitHubIssues, "3"},
+		{"prs", src.GetGitHubPRs, "2"},
+		{"followers", src.GetGitHubFollowers, "13"},
+	}
+
+	for _, test := range tests {
+		if result := test.function(config).Display; result != test.expected {
+			t.Errorf("For %s, expected %s, but got %s", test.name, test.expected, result)
+		}
+	}
+
+	if standIn.requests["/repos/grosheth/gysmo"] != 1 {
+		t.Errorf("Expected the repo to be fetched once, but got %d requests", standIn.requests["/repos/grosheth/gysmo"])
+	}
+	if len(standIn.tokens) == 0 || standIn.tokens[0] != "Bearer secret" {
+		t.Errorf("Expected the token from GYSMO_TEST_TOKEN to be sent, got %v", standIn.tokens)
+	}
+}
+
+func TestGitHubTokenFile(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(tokenFile, []byte("from-file\n"), 0600)
+
+	if token := src.GitHubToken(src.GitHubConfig{TokenFile: tokenFile}); token != "from-file" {
+		t.Errorf("Expected from-file, but got %q", token)
+	}
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	if token := src.GitHubToken(src.GitHubConfig{TokenFile: tokenFile}); token != "from-env" {
+		t.Errorf("Expected from-env, but got %q", token)
+	}
+}
+
+func TestGitHubETagRevalidation(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	config := src.GitHubConfig{Repo: "grosheth/gysmo", APIURL: standIn.server.URL}
+
+	// An expired entry with an ETag gets revalidated instead of downloaded again
+	dataDir := filepath.Join(os.Getenv("HOME"), ".config", "gysmo", "data")
+	os.MkdirAll(dataDir, 0755)
+	key := "github " + standIn.server.URL + "/repos/grosheth/gysmo"
+	stale := fmt.Sprintf(`{%q: {"value": "{\"stargazers_count\": 100}", "updated_at": "2020-01-01T00:00:00Z", "etag": "\"v1\""}}`, key)
+	os.WriteFile(filepath.Join(dataDir, "cache.json"), []byte(stale), 0644)
+
+	if result := src.GetGitHubStars(config).Display; result != "100" {
+		t.Errorf("Expected cached 100 after 304, but got %s", result)
+	}
+	if standIn.requests["/repos/grosheth/gysmo"] != 1 {
+		t.Errorf("Expected one revalidation request, but got %d", standIn.requests["/repos/grosheth/gysmo"])
+	}
+}
+
+func TestGitHubRateLimit(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	standIn.remaining = "0"
+	config := src.GitHubConfig{Repo: "grosheth/gysmo", APIURL: standIn.server.URL, CacheTTL: -1}
+
+	if result := src.GetGitHubStars(config).Display; result != "42" {
+		t.Errorf("Expected 42, but got %s", result)
+	}
+	if result := src.GetGitHubFollowers(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found while rate limited, but got %s", result)
+	}
+	if standIn.requests["/users/grosheth"] != 0 {
+		t.Errorf("Expected no request while rate limited, but got %d", standIn.requests["/users/grosheth"])
+	}
+}
+
+func TestGitHubRateLimitPerResource(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	standIn.searchRemaining = "0"
+	config := src.GitHubConfig{Repo: "grosheth/gysmo", APIURL: standIn.server.URL, CacheTTL: -1}
+
+	if result := src.GetGitHubPRs(config).Display; result != "2" {
+		t.Errorf("Expected 2, but got %s", result)
+	}
+	if result := src.GetGitHubPRs(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found while the search limit is exhausted, but got %s", result)
+	}
+	if result := src.GetGitHubStars(config).Display; result != "42" {
+		t.Errorf("Expected the core limit to be unaffected by the search one, but got %s", result)
+	}
+	if standIn.requests["/search/issues"] != 1 {
+		t.Errorf("Expected one search request, but got %d", standIn.requests["/search/issues"])
+	}
+
+	// Another API keeps its own limits
+	config.APIURL = strings.Replace(standIn.server.URL, "127.0.0.1", "localhost", 1)
+	if result := src.GetGitHubPRs(config).Display; result != "2" {
+		t.Errorf("Expected the limit of another API to be unaffected, but got %s", result)
+	}
+}
+
+func TestGitHubRetryAfter(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	standIn.retryAfter = "60"
+	config := src.GitHubConfig{User: "grosheth", APIURL: standIn.server.URL, CacheTTL: -1}
+
+	for range 2 {
+		if result := src.GetGitHubFollowers(config).Display; result != "Not Found" {
+			t.Errorf("Expected Not Found, but got %s", result)
+		}
+	}
+	if standIn.requests["/users/grosheth"] != 1 {
+		t.Errorf("Expected no request before Retry-After, but got %d requests", standIn.requests["/users/grosheth"])
+	}
+}
+
+func TestGitHubItemRepo(t *testing.T) {
+	standIn := setupGitHubTest(t)
+	config := src.GitHubConfig{Repo: "grosheth/gysmo", APIURL: standIn.server.URL}
+
+	item := src.ConfigItem{Text: "stars", Keyword: "github_stars", Repo: "octocat/hello-world"}
+	if result := src.GetGitHubItemValue(item, config).Display; result != "3" {
+		t.Errorf("Expected the stars of the item repo, but got %s", result)
+	}
+	if item.Key() != "github_stars octocat/hello-world" {
+		t.Errorf("Expected the repo in the key, but got %q", item.Key())
+	}
+
+	item = src.ConfigItem{Text: "followers", Keyword: "github_followers", User: "octocat"}
+	if result := src.GetGitHubItemValue(item, config).Display; result != "99" {
+		t.Errorf("Expected the followers of the item user, but got %s", result)
+	}
+
+	// The other fields fall back to the github section
+	item = src.ConfigItem{Text: "followers", Keyword: "github_followers", Repo: "octocat/hello-world"}
+	config.User = "grosheth"
+	if result := src.GetGitHubItemValue(item, config).Display; result != "13" {
+		t.Errorf("Expected the user of the github section, but got %s", result)
+	}
+
+	items := []src.ConfigItem{{Keyword: "github_stars", Repo: "octocat/hello-world"}, {Keyword: "github_forks"}}
+	if keywords := src.ItemKeywords(items); len(keywords) != 1 || keywords[0] != "github_forks" {
+		t.Errorf("Expected only the keywords using the github section, got %v", keywords)
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestPaintAsciiArtWithGradient(t *testing.T) {
+	ascii := src.AsciiConfig{
+		Colors:   src.AsciiColors{"red", "green"},
+		Gradient: src.Gradient{Stops: []string{"#ff0000", "#0000ff"}},
+	}
+
+	painted := src.PaintAsciiArt("${c2}# #\n", ascii)
+	expected := "\033[38;2;255;0;0m# \033[38;2;0;0;255m#\n"
+	if painted != expected {
+		t.Errorf("Expected a horizontal gradient without placeholders, got %q", painted)
+	}
+
+	ascii.Gradient = src.Gradient{Stops: []string{"#000", "#fff", "#000"}, Direction: "vertical"}
+	painted = src.PaintAsciiArt("#\n#\n#\n", ascii)
+	expected = "\033[38;2;0;0;0m#\n\033[38;2;255;255;255m#\n\033[38;2;0;0;0m#\n"
+	if painted != expected {
+		t.Errorf("Expected a vertical gradient through three stops, got %q", painted)
+	}
+
+	ascii.Gradient = src.Gradient{Stops: []string{"#000000", "#646464"}, Direction: "diagonal"}
+	painted = src.PaintAsciiArt("##\n##", ascii)
+	expected = "\033[38;2;0;0;0m#\033[38;2;50;50;50m#\n\033[38;2;50;50;50m#\033[38;2;100;100;100m#"
+	if painted != expected {
+		t.Errorf("Expected a diagonal gradient, got %q", painted)
+	}
+}
+
+func TestGradientGraphemeClusters(t *testing.T) {
+	ascii := src.AsciiConfig{Gradient: src.Gradient{Stops: []string{"#000000", "#ffffff"}}}
+
+	// The combining accent and the family emoji take one color each, the emoji counting for two cells
+	painted := src.PaintAsciiArt("e\u0301\U0001F468\u200D\U0001F469\u200D\U0001F467x", ascii)
+	expected := "\033[38;2;0;0;0me\u0301" +
+		"\033[38;2;85;85;85m\U0001F468\u200D\U0001F469\u200D\U0001F467" +
+		"\033[38;2;255;255;255mx"
+	if painted != expected {
+		t.Errorf("Expected one color per grapheme cluster, got %q", painted)
+	}
+}
+
+func TestBuildBoxMenuWithHeaderGradient(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Header.Text = "gysmo"
+	config.Header.InBorder = true
+	config.Header.Gradient = src.Gradient{Stops: []string{"#ff0000", "#0000ff"}}
+	config.Footer.Text = "foot\nnote"
+	config.Footer.Gradient = src.Gradient{Stops: []string{"#00ff00", "#0000ff"}, Direction: "vertical"}
+
+	menu := src.BuildBoxMenu(nil, "", config)
+	if !strings.Contains(menu, "\033[38;2;255;0;0mg") || !strings.Contains(menu, "\033[38;2;0;0;255mo") {
+		t.Errorf("Expected the title in the border to go from red to blue, got %q", menu)
+	}
+	if !strings.Contains(menu, "\033[38;2;0;255;0mf") || !strings.Contains(menu, "\033[38;2;0;0;255mn") {
+		t.Errorf("Expected the footer lines to go from green to blue, got %q", menu)
+	}
+	assertAligned(t, menu)
+}
+
+func TestGradientFallback(t *testing.T) {
+	ascii := src.AsciiConfig{Gradient: src.Gradient{Stops: []string{"#ff0000", "#0000ff"}}}
+	painted := src.PaintAsciiArt("##", ascii)
+
+	if downsampled := src.ApplyColorLevel(painted, src.Color256); downsampled != "\033[38;5;196m#\033[38;5;21m#" {
+		t.Errorf("Expected 256 colors, got %q", downsampled)
+	}
+	if downsampled := src.ApplyColorLevel(painted, src.Color16); downsampled != "\033[91m#\033[34m#" {
+		t.Errorf("Expected 16 colors, got %q", downsampled)
+	}
+}
+
+func TestValidateGradients(t *testing.T) {
+	config := src.Config{}
+	config.Header.Gradient = src.Gradient{Stops: []string{"#ff0000"}}
+	if err := src.ValidateGradients(config); err == nil || !strings.Contains(err.Error(), "header.gradient") {
+		t.Errorf("Expected an error for a single stop, got %v", err)
+	}
+
+	config.Header.Gradient = src.Gradient{}
+	for _, stop := range []string{"blue", "208", "accent", "nope"} {
+		config.Ascii.Gradient = src.Gradient{Stops: []string{"#ff0000", stop}}
+		if err := src.ValidateGradients(config); err == nil || !strings.Contains(err.Error(), "ascii.gradient") {
+			t.Errorf("Expected an error for the stop %q that is not RGB, got %v", stop, err)
+		}
+	}
+
+	config.Ascii.Gradient = src.Gradient{Stops: []string{"#f00", "rgb(0, 0, 255)", "#00FF00"}}
+	if err := src.ValidateGradients(config); err != nil {
+		t.Errorf("Expected RGB stops to be valid, got %v", err)
+	}
+}
+
+func TestGradientThemeStops(t *testing.T) {
+	defer src.SetTheme(mustLoadTheme(t, ""))
+	src.SetTheme(mustLoadTheme(t, "nord"))
+
+	config := src.Config{}
+	config.Ascii.Gradient = src.Gradient{Stops: []string{"c1", "Accent"}}
+	if err := src.ValidateGradients(config); err != nil {
+		t.Errorf("Expected the slots of an RGB theme to be valid stops, got %v", err)
+	}
+
+	ascii := src.AsciiConfig{Gradient: config.Ascii.Gradient}
+	if painted := src.PaintAsciiArt("##", ascii); painted != "\033[38;2;191;97;106m#\033[38;2;136;192;208m#" {
+		t.Errorf("Expected the gradient to go through the colors of the theme, got %q", painted)
+	}
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func getGridConfig(grid src.GridConfig) src.Config {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Header.Enabled = false
+	config.Footer.Enabled = false
+	config.General.MenuType = "grid"
+	config.General.MenuPadding = 0
+	config.General.Grid = grid
+	config.Items = []src.ConfigItem{
+		{Text: "user", Value: "grosheth"},
+		{Text: "shell", Value: "zsh"},
+		{Text: "os", Value: "Ubuntu"},
+		{Text: "kernel", Value: "6.8"},
+		{Text: "gpu", Value: "Intel Corporation Alder Lake-P GT2"},
+	}
+	return config
+}
+
+// gridLines returns the lines of the grid, without the blank lines of the disabled ASCII art.
+func gridLines(config src.Config) []string {
+	lines := []string{}
+	for _, line := range strings.Split(src.StripAnsiCodes(src.BuildGridMenu(nil, "", config)), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestBuildGridMenuRowOrder(t *testing.T) {
+	lines := gridLines(getGridConfig(src.GridConfig{Columns: 3}))
+
+	expected := []string{
+		"user    grosheth │ shell  zsh                                │ os  Ubuntu",
+		"kernel  6.8      │ gpu    Intel Corporation Alder Lake-P GT2",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
+
+func TestBuildGridMenuColumnOrder(t *testing.T) {
+	lines := gridLines(getGridConfig(src.GridConfig{Columns: 3, Order: "column", Separator: " | "}))
+
+	expected := []string{
+		"user   grosheth | os      Ubuntu | gpu  Intel Corporation Alder Lake-P GT2",
+		"shell  zsh      | kernel  6.8",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
+
+func TestBuildGridMenuBoxed(t *testing.T) {
+	config := getGridConfig(src.GridConfig{Columns: 2, Boxed: true})
+	lines := gridLines(config)
+	if len(lines) != 7 || !strings.HasPrefix(lines[0], "╭") || !strings.Contains(lines[0], "┬") || !strings.HasPrefix(lines[6], "╰") {
+		t.Errorf("Expected three rows in a table, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if lines[1] != "│ user  grosheth                           │ shell   zsh │" {
+		t.Errorf("Expected the cells to be padded to their column, got %q", lines[1])
+	}
+
+	for _, policy := range []string{"narrow", "wide"} {
+		setAmbiguousWidth(t, policy)
+		lines := gridLines(config)
+		widths := map[int]bool{}
+		for _, line := range lines {
+			widths[src.DisplayWidth(line)] = true
+		}
+		if len(widths) != 1 {
+			t.Errorf("Expected every line of the table to have the same width with %s, got:\n%s", policy, strings.Join(lines, "\n"))
+		}
+	}
+}
+
+func TestBuildGridMenuWithWidth(t *testing.T) {
+	config := getGridConfig(src.GridConfig{Columns: 3, Boxed: true})
+	config.General.Width = 60
+	config.Items[4].Overflow = "wrap"
+	config.Items = append(config.Items, src.ConfigItem{Text: "hidden", Value: "a value that does not fit", Overflow: "hide"})
+
+	menu := src.BuildGridMenu(nil, "", config)
+	assertFitsWidth(t, menu, 60)
+	if !strings.Contains(menu, "Intel C…") {
+		t.Errorf("Expected wrapped values to be truncated in cells, got:\n%s", menu)
+	}
+	if strings.Contains(menu, "hidden") {
+		t.Errorf("Expected the item that does not fit to be hidden, got:\n%s", menu)
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"encoding/json"
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+const groupedItems = `[
+	{ "text": "user", "keyword": "user", "icon": "" },
+	{ "group": "Hardware", "text_color": "red", "line_color": "blue", "items": [
+		{ "text": "cpu", "keyword": "cpu", "icon": "" },
+		{ "text": "gpu", "keyword": "gpu", "icon": "" },
+		{ "text": "ram", "keyword": "ram", "icon": "" }
+	] },
+	{ "group": "Power", "collapse": true, "items": [
+		{ "text": "battery", "keyword": "battery", "icon": "" }
+	] },
+	{ "text": "shell", "keyword": "shell", "icon": "" }
+]`
+
+var groupValues = src.TextValues(map[string]string{
+	"user": "grosheth", "shell": "zsh", "cpu": "i7", "gpu": "Iris", "ram": "16G", "battery": "Not Found",
+})
+
+func getGroupConfig(t *testing.T) src.Config {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.General.MenuPadding = 0
+	if err := json.Unmarshal([]byte(groupedItems), &config.Items); err != nil {
+		t.Fatalf("Failed to unmarshal items: %v", err)
+	}
+	return config
+}
+
+func TestUnmarshalGroups(t *testing.T) {
+	config := getGroupConfig(t)
+
+	texts := []string{}
+	for _, item := range config.Items {
+		texts = append(texts, item.Text)
+	}
+	if strings.Join(texts, " ") != "user cpu gpu ram battery shell" {
+		t.Errorf("Expected the groups to be flattened, got %v", texts)
+	}
+	if config.Items[0].Group != nil || config.Items[5].Group != nil {
+		t.Errorf("Expected items outside of groups to have no group")
+	}
+	if group := config.Items[1].Group; group == nil || group.Title != "Hardware" || group.TextColor != "red" || config.Items[3].Group != group {
+		t.Errorf("Expected the items of a group to share it, got %+v", group)
+	}
+}
+
+func TestBuildBoxMenuWithGroups(t *testing.T) {
+	config := getGroupConfig(t)
+	menu := src.BuildBoxMenu(groupValues, "", config)
+	plain := src.StripAnsiCodes(menu)
+
+	expected := "│ user     │ grosheth\n├──────────┤\n│ Hardware │\n│ cpu      │ i7\n"
+	if !strings.Contains(plain, expected) {
+		t.Errorf("Expected the group title after a separator, got:\n%s", plain)
+	}
+	if !strings.Contains(plain, "│ ram      │ 16G\n├──────────┤\n│ shell    │ zsh\n") {
+		t.Errorf("Expected a separator after the group, got:\n%s", plain)
+	}
+	if strings.Contains(plain, "Power") || strings.Contains(plain, "battery") {
+		t.Errorf("Expected the collapsing group without value to be hidden, got:\n%s", plain)
+	}
+	if !strings.Contains(menu, src.Red+"Hardware") || !strings.Contains(menu, src.Blue+"──────────") {
+		t.Errorf("Expected the colors of the group, got %q", menu)
+	}
+	assertAligned(t, menu)
+}
+
+func TestBuildBoxMenuWithHiddenGroup(t *testing.T) {
+	config := getGroupConfig(t)
+	config.General.Breakpoints = []src.Breakpoint{{Hide: []string{"cpu", "gpu", "ram"}}}
+	config = src.ApplyBreakpoints(config, 80, 24)
+
+	plain := src.StripAnsiCodes(src.BuildBoxMenu(groupValues, "", config))
+	if strings.Contains(plain, "Hardware") || strings.Count(plain, "├") != 2 {
+		t.Errorf("Expected a group without items to disappear with its separator, got:\n%s", plain)
+	}
+}
+
+func TestBuildListMenuWithGroupsInColumns(t *testing.T) {
+	config := getGroupConfig(t)
+	config.General.Columns = true
+	plain := src.StripAnsiCodes(src.BuildListMenu(groupValues, "", config))
+
+	lines := strings.Split(plain, "\n")
+	index := -1
+	for i, line := range lines {
+		if line == "Hardware" {
+			index = i
+		}
+	}
+	if index < 0 || !strings.Contains(lines[index+1], "cpu") || !strings.Contains(lines[index+1], "ram") || !strings.Contains(lines[index+2], "gpu") {
+		t.Errorf("Expected the columns to be split inside the group, got:\n%s", plain)
+	}
+	if strings.Contains(lines[index-2], "|") {
+		t.Errorf("Expected items outside of the group not to share its columns, got:\n%s", plain)
+	}
+}
+
+func TestBuildGridMenuWithGroups(t *testing.T) {
+	config := getGroupConfig(t)
+	plain := src.StripAnsiCodes(src.BuildGridMenu(groupValues, "", config))
+
+	if !strings.Contains(plain, "Hardware\ncpu  i7  │ gpu  Iris\nram  16G\n") {
+		t.Errorf("Expected the group to be laid out on its own, got:\n%s", plain)
+	}
+}
+
+func TestBuildInlineMenuWithGroups(t *testing.T) {
+	config := getGroupConfig(t)
+	config.General.MenuType = "inline"
+	plain := src.StripAnsiCodes(src.BuildInlineMenu(groupValues, config))
+
+	if !strings.Contains(plain, "i7") || !strings.Contains(plain, "zsh") {
+		t.Errorf("Expected the items of the groups, got %q", plain)
+	}
+	if strings.Contains(plain, "battery") || strings.Contains(plain, "Not Found") {
+		t.Errorf("Expected the collapsing group without value to be left out, got %q", plain)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func getHeaderConfig() src.Config {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.General.MenuPadding = 0
+	config.Items = []src.ConfigItem{{Text: "user", Keyword: "user"}}
+	config.Header.Text = "gysmo\n{{.user}}@{{.hostname}}"
+	config.Footer.Text = "bye"
+	return config
+}
+
+var headerItems = src.TextValues(map[string]string{"user": "grosheth", "hostname": "box"})
+
+func TestBuildBoxMenuWithAlignedHeader(t *testing.T) {
+	config := getHeaderConfig()
+	config.Header.Align = "center"
+	config.Footer.Align = "right"
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(headerItems, "", config))
+
+	for _, expected := range []string{"│    gysmo     │\n", "│ grosheth@box │\n", "│          bye │\n"} {
+		if !strings.Contains(menu, expected) {
+			t.Errorf("Expected the menu to contain %q, got:\n%s", expected, menu)
+		}
+	}
+	assertAligned(t, menu)
+}
+
+func TestBuildBoxMenuWithHeaderInBorder(t *testing.T) {
+	config := getHeaderConfig()
+	config.Header.InBorder = true
+	config.Footer.InBorder = true
+	config.Footer.Align = "right"
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(headerItems, "", config))
+	lines := strings.Split(strings.TrimSpace(menu), "\n")
+	if lines[0] != "╭─ gysmo ──────╮" || lines[1] != "│ grosheth@box │" || lines[len(lines)-1] != "╰──────── bye ─╯" {
+		t.Errorf("Expected the header and footer in the border, got:\n%s", menu)
+	}
+	if strings.Count(menu, "├") != 1 {
+		t.Errorf("Expected no separator for a footer only in the border, got:\n%s", menu)
+	}
+
+	config.Header.Align = "center"
+	for _, policy := range []string{"narrow", "wide"} {
+		setAmbiguousWidth(t, policy)
+		assertAligned(t, src.BuildBoxMenu(headerItems, "", config))
+	}
+}
+
+func TestBuildListMenuWithHeaderInBorder(t *testing.T) {
+	config := getHeaderConfig()
+	config.Header.InBorder = true
+	config.Footer.InBorder = true
+	config.Footer.Align = "right"
+
+	menu := src.StripAnsiCodes(src.BuildListMenu(headerItems, "", config))
+	lines := strings.Split(strings.TrimSpace(menu), "\n")
+	if lines[0] != "─ gysmo ──────" || lines[1] != "grosheth@box" || lines[len(lines)-1] != "──────── bye ─" {
+		t.Errorf("Expected the header and footer in their lines, got:\n%s", menu)
+	}
+}
+
+func TestConfigKeywordsIncludesHeaderAndFooter(t *testing.T) {
+	config := getHeaderConfig()
+	config.Footer.Text = "{{.os_name}} {{.user}}"
+
+	expected := []string{"user", "hostname", "os_name"}
+	if result := src.ConfigKeywords(config); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestValidateTemplatesInHeader(t *testing.T) {
+	config := getHeaderConfig()
+	config.Header.Text = "{{.user"
+	if err := src.ValidateTemplates(config); err == nil || !strings.Contains(err.Error(), "header") {
+		t.Errorf("Expected an error for the header template, got %v", err)
+	}
+}
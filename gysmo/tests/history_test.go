@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		samples  []float64
+		percent  bool
+		expected string
+	}{
+		{[]float64{0, 50, 100}, true, "▁▅█"},
+		{[]float64{10, 20, 30}, true, "▂▂▃"},
+		{[]float64{10, 20, 30}, false, "▁▅█"},
+		{[]float64{5, 5}, false, "▅▅"},
+		{nil, true, ""},
+	}
+
+	for _, test := range tests {
+		if result := src.RenderSparkline(test.samples, test.percent); result != test.expected {
+			t.Errorf("For %v, expected %s, but got %s", test.samples, test.expected, result)
+		}
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config := src.HistoryConfig{Samples: 3}
+
+	for _, percent := range []float64{10, 20, 30, 40} {
+		items := map[string]src.Value{
+			"ram %": src.PercentValue(percent),
+			"user":  src.TextValue("grosheth"),
+			"gpu %": src.TextValue("Not Found"),
+		}
+		src.RecordHistory(items, config)
+	}
+
+	history := src.LoadHistory()
+	samples := history["ram %"]
+	if len(samples) != 3 || samples[0].Value != 20 || samples[2].Value != 40 {
+		t.Errorf("Expected the last 3 samples of ram %%, but got %+v", samples)
+	}
+	if samples[0].Time.IsZero() {
+		t.Errorf("Expected samples to have a time")
+	}
+	if _, exists := history["user"]; exists {
+		t.Errorf("Expected text values to be left out of the history")
+	}
+	if _, exists := history["gpu %"]; exists {
+		t.Errorf("Expected missing values to be left out of the history")
+	}
+}
+
+func TestRecordHistoryDisabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	src.RecordHistory(map[string]src.Value{"ram %": src.PercentValue(10)}, src.HistoryConfig{Samples: -1})
+	if history := src.LoadHistory(); len(history) != 0 {
+		t.Errorf("Expected no history, but got %+v", history)
+	}
+}
+
+func TestBuildBoxMenuWithSparkline(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{
+		{Text: "Disk", Keyword: "drive %", Display: "sparkline", Sparkline: &src.SparklineOptions{Samples: 3}},
+		{Text: "RAM", Keyword: "ram %", Display: "sparkline"},
+	}
+	drive := src.PercentValue(100)
+	drive.History = []float64{0, 0, 50, 100}
+	items := map[string]src.Value{"drive %": drive, "ram %": src.PercentValue(42)}
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	if !strings.Contains(menu, "│ Disk   │ ▁▅█ 100.00%\n") {
+		t.Errorf("Expected the last 3 samples as a sparkline, got:\n%s", menu)
+	}
+	if !strings.Contains(menu, "│ RAM    │ 42.00%\n") {
+		t.Errorf("Expected a value without history to stay as text, got:\n%s", menu)
+	}
+}
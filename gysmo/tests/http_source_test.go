@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"fmt"
+	"gysmo/gysmo/src"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupHTTPSourceTest(t *testing.T) (*httptest.Server, *int) {
+	t.Setenv("HOME", t.TempDir())
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/states/sensor.living_room", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer hass-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"state": "21.5", "attributes": {"unit": "°C"}}`)
+	})
+	mux.HandleFunc("/ci", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"data": [{"value": 98.25, "passing": true}, {"value": "second"}]}`)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+			fmt.Fprint(w, `{"value": "late"}`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	originalDo := src.HttpSourceDo
+	src.HttpSourceDo = server.Client().Do
+	t.Cleanup(func() { src.HttpSourceDo = originalDo })
+	return server, &requests
+}
+
+func TestGetHTTPValue(t *testing.T) {
+	server, _ := setupHTTPSourceTest(t)
+	t.Setenv("HASS_TOKEN", "hass-token")
+
+	tests := []struct {
+		source   src.HTTPSource
+		expected string
+	}{
+		{src.HTTPSource{URL: server.URL + "/api/states/sensor.living_room", Pointer: "/state", Headers: map[string]string{"Authorization": "Bearer ${HASS_TOKEN}"}}, "21.5"},
+		{src.HTTPSource{URL: server.URL + "/ci", Pointer: "/data/0/value"}, "98.25"},
+		{src.HTTPSource{URL: server.URL + "/ci", Pointer: "/data/0/passing"}, "true"},
+		{src.HTTPSource{URL: server.URL + "/ci", Pointer: "/data/1"}, `{"value":"second"}`},
+		{src.HTTPSource{URL: server.URL + "/ci", Pointer: "/missing"}, "Not Found"},
+		{src.HTTPSource{URL: server.URL + "/api/states/sensor.living_room", Pointer: "/state"}, "Not Found"},
+	}
+
+	for _, test := range tests {
+		test.source.CacheTTL = -1
+		if result := src.GetHTTPValue(test.source).Display; result != test.expected {
+			t.Errorf("For %s%s, expected %s, but got %s", test.source.URL, test.source.Pointer, test.expected, result)
+		}
+	}
+}
+
+func TestGetHTTPValueTimeout(t *testing.T) {
+	server, _ := setupHTTPSourceTest(t)
+
+	start := time.Now()
+	result := src.GetHTTPValue(src.HTTPSource{URL: server.URL + "/slow", Pointer: "/value", Timeout: 1, CacheTTL: -1}).Display
+	if result != "Not Found" {
+		t.Errorf("Expected Not Found on timeout, but got %s", result)
+	}
+	if time.Since(start) >= 2*time.Second {
+		t.Errorf("Expected the request to be cancelled after 1s")
+	}
+}
+
+func TestGetHTTPValueLongTimeout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	// Slower than the 3s of the other HTTP requests, the source must wait for its own timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(3500 * time.Millisecond):
+			fmt.Fprint(w, `{"value": "late"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	result := src.GetHTTPValue(src.HTTPSource{URL: server.URL, Pointer: "/value", Timeout: 5, CacheTTL: -1}).Display
+	if result != "late" {
+		t.Errorf("Expected the value after 3.5s with a timeout of 5s, but got %s", result)
+	}
+}
+
+func TestHTTPSourceHeaders(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HASS_TOKEN", "hass-token")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"authorization": %q}`, r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(server.Close)
+	originalDo := src.HttpSourceDo
+	src.HttpSourceDo = server.Client().Do
+	t.Cleanup(func() { src.HttpSourceDo = originalDo })
+
+	tests := map[string]string{
+		"Bearer ${HASS_TOKEN}": "Bearer hass-token",
+		"Bearer a$b$HOME":      "Bearer a$b$HOME",
+		"${MISSING}x":          "x",
+	}
+	for header, expected := range tests {
+		source := src.HTTPSource{URL: server.URL, Pointer: "/authorization", Headers: map[string]string{"Authorization": header}}
+		if result := src.GetHTTPValue(source).Display; result != expected {
+			t.Errorf("For header %q, expected %q, but got %q", header, expected, result)
+		}
+	}
+}
+
+func TestHTTPSourceKeyWithHeaders(t *testing.T) {
+	first := src.ConfigItem{HTTP: &src.HTTPSource{URL: "http://api", Pointer: "/a", Headers: map[string]string{"X-User": "first"}}}
+	second := src.ConfigItem{HTTP: &src.HTTPSource{URL: "http://api", Pointer: "/a", Headers: map[string]string{"X-User": "second"}}}
+	plain := src.ConfigItem{HTTP: &src.HTTPSource{URL: "http://api", Pointer: "/a"}}
+
+	if first.Key() == second.Key() {
+		t.Errorf("Expected sources with different headers to have different keys, got %q", first.Key())
+	}
+	if plain.Key() != "http http://api#/a" {
+		t.Errorf("Expected the key of a source without headers to be unchanged, got %q", plain.Key())
+	}
+	if strings.Contains(first.Key(), "first") {
+		t.Errorf("Expected header values not to be written in the key, got %q", first.Key())
+	}
+}
+
+func TestGetHTTPValueCache(t *testing.T) {
+	server, requests := setupHTTPSourceTest(t)
+	source := src.HTTPSource{URL: server.URL + "/ci", Pointer: "/data/0/value", CacheTTL: 60}
+
+	src.GetHTTPValue(source)
+	if result := src.GetHTTPValue(source); !result.Numeric || result.Raw != 98.25 {
+		t.Errorf("Expected numeric 98.25, but got %+v", result)
+	}
+	if *requests != 1 {
+		t.Errorf("Expected one request, but got %d", *requests)
+	}
+}
+
+func TestBuildBoxMenuWithHTTPItem(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	item := src.ConfigItem{Text: "temp", HTTP: &src.HTTPSource{URL: "http://hass.local/api", Pointer: "/state"}}
+	config.Items = []src.ConfigItem{item}
+	items := map[string]src.Value{item.Key(): src.ParseValue("21.5")}
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	if !strings.Contains(menu, "│ temp   │ 21.5") {
+		t.Errorf("Expected the HTTP value in the menu, got:\n%s", menu)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func getInlineConfig(inline src.InlineConfig) src.Config {
+	config := GetConfigWithAscii("left")
+	config.General.MenuType = "inline"
+	config.General.Inline = inline
+	config.Items = []src.ConfigItem{
+		{Text: "user", Keyword: "user", Icon: "U", IconColor: "red"},
+		{Text: "shell", Keyword: "shell"},
+		{Text: "kernel", Keyword: "kernel", Icon: "K"},
+	}
+	return config
+}
+
+var inlineItems = src.TextValues(map[string]string{"user": "grosheth", "shell": "zsh", "kernel": "6.8.0-45-generic"})
+
+func TestBuildInlineMenu(t *testing.T) {
+	menu := src.BuildInlineMenu(inlineItems, getInlineConfig(src.InlineConfig{}))
+
+	if result := src.StripAnsiCodes(menu); result != "U grosheth | shell zsh | K 6.8.0-45-generic" {
+		t.Errorf("Expected the items on a single line, got %q", result)
+	}
+	if !strings.Contains(menu, src.Red+"U"+src.Reset) {
+		t.Errorf("Expected the icon color of the item, got %q", menu)
+	}
+}
+
+func TestBuildInlineMenuPowerline(t *testing.T) {
+	config := getInlineConfig(src.InlineConfig{Style: "powerline", Backgrounds: []string{"blue", "purple"}})
+	menu := src.BuildInlineMenu(inlineItems, config)
+
+	if result := src.StripAnsiCodes(menu); result != " U grosheth \ue0b0 shell zsh \ue0b0 K 6.8.0-45-generic \ue0b0" {
+		t.Errorf("Expected powerline segments, got %q", result)
+	}
+	for _, expected := range []string{
+		src.GetBackgroundColorCode("blue") + " ",
+		src.Blue + src.GetBackgroundColorCode("purple") + "\ue0b0",
+		src.Purple + src.GetBackgroundColorCode("blue") + "\ue0b0",
+		src.Blue + "\ue0b0" + src.Reset,
+	} {
+		if !strings.Contains(menu, expected) {
+			t.Errorf("Expected the arrows to go from one background to the next, %q is missing from %q", expected, menu)
+		}
+	}
+}
+
+func TestBuildInlineMenuWithMaxWidth(t *testing.T) {
+	tests := []struct {
+		inline   src.InlineConfig
+		overflow string
+		expected string
+	}{
+		{src.InlineConfig{MaxWidth: 30}, "", "U grosheth | shell zsh | K 6.…"},
+		{src.InlineConfig{MaxWidth: 30}, "hide", "U grosheth | shell zsh"},
+		{src.InlineConfig{MaxWidth: 12}, "", "U grosheth"},
+		{src.InlineConfig{Style: "powerline", MaxWidth: 32}, "", " U grosheth \ue0b0 shell zsh \ue0b0 K 6… \ue0b0"},
+	}
+
+	for _, test := range tests {
+		config := getInlineConfig(test.inline)
+		config.Items[2].Overflow = test.overflow
+		menu := src.BuildInlineMenu(inlineItems, config)
+		if result := src.StripAnsiCodes(menu); result != test.expected {
+			t.Errorf("For %+v, expected %q, but got %q", test.inline, test.expected, result)
+		}
+		if src.DisplayWidth(menu) > test.inline.MaxWidth {
+			t.Errorf("Expected at most %d cells, but got %d", test.inline.MaxWidth, src.DisplayWidth(menu))
+		}
+	}
+}
+
+func TestBuildInlineMenuFallsBackToWidth(t *testing.T) {
+	config := getInlineConfig(src.InlineConfig{})
+	config.General.Width = 22
+	if result := src.StripAnsiCodes(src.BuildInlineMenu(inlineItems, config)); result != "U grosheth | shell zsh" {
+		t.Errorf("Expected general.width to limit the line, got %q", result)
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAsciiArtAuto(t *testing.T) {
+	asciiDir := t.TempDir()
+	ascii := src.AsciiConfig{Path: "auto", Colors: src.AsciiColors{""}}
+
+	tests := []struct {
+		osRelease src.OSRelease
+		contains  string
+		colors    src.AsciiColors
+	}{
+		{src.OSRelease{ID: "arch"}, "_-''", src.AsciiColors{"cyan"}},
+		{src.OSRelease{ID: "endeavouros", ID_LIKE: "arch"}, "_-''", src.AsciiColors{"cyan"}},
+		{src.OSRelease{ID: "pop", ID_LIKE: "ubuntu debian"}, "(   )", src.AsciiColors{"red"}},
+		{src.OSRelease{ID: "opensuse-tumbleweed"}, "`._____.'", src.AsciiColors{"green", "white"}},
+		{src.OSRelease{ID: "nixos"}, "====", src.AsciiColors{"blue", "cyan"}},
+		{src.OSRelease{ID: "unknown"}, "#####", src.AsciiColors{"bright_black", "white", "yellow"}},
+		{src.OSRelease{}, "#####", src.AsciiColors{"bright_black", "white", "yellow"}},
+	}
+
+	for _, test := range tests {
+		asciiArt, colors, err := src.LoadAsciiArt(asciiDir, ascii, test.osRelease)
+		if err != nil {
+			t.Fatalf("Expected no error for %+v, got %v", test.osRelease, err)
+		}
+		if !strings.Contains(asciiArt, test.contains) {
+			t.Errorf("Expected the logo of %q to contain %q, got:\n%s", test.osRelease.ID, test.contains, asciiArt)
+		}
+		if strings.Join(colors, ",") != strings.Join(test.colors, ",") {
+			t.Errorf("Expected the colors %v for %q, got %v", test.colors, test.osRelease.ID, colors)
+		}
+	}
+}
+
+func TestLoadAsciiArtSmall(t *testing.T) {
+	ascii := src.AsciiConfig{Path: "auto", Size: "small", Colors: src.AsciiColors{"green"}}
+
+	large, _, _ := src.LoadAsciiArt(t.TempDir(), src.AsciiConfig{Path: "auto"}, src.OSRelease{ID: "fedora"})
+	small, colors, err := src.LoadAsciiArt(t.TempDir(), ascii, src.OSRelease{ID: "fedora"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Count(small, "\n") >= strings.Count(large, "\n") {
+		t.Errorf("Expected the small logo to be smaller, got:\n%s", small)
+	}
+	if len(colors) != 1 || colors[0] != "green" {
+		t.Errorf("Expected ascii.colors to win over the colors of the logo, got %v", colors)
+	}
+}
+
+func TestLoadAsciiArtUserOverride(t *testing.T) {
+	asciiDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(asciiDir, "debian"), []byte("my debian\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	asciiArt, _, err := src.LoadAsciiArt(asciiDir, src.AsciiConfig{Path: "auto"}, src.OSRelease{ID: "debian"})
+	if err != nil || asciiArt != "my debian\n" {
+		t.Errorf("Expected the file of the user, got %q, %v", asciiArt, err)
+	}
+
+	// The small size falls back to the large file of the user before the built-in logos
+	asciiArt, _, _ = src.LoadAsciiArt(asciiDir, src.AsciiConfig{Path: "auto", Size: "small"}, src.OSRelease{ID: "debian"})
+	if asciiArt != "my debian\n" {
+		t.Errorf("Expected the file of the user before the built-in small logo, got %q", asciiArt)
+	}
+
+	if err := os.WriteFile(filepath.Join(asciiDir, "debian_small"), []byte("my small debian\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	asciiArt, _, _ = src.LoadAsciiArt(asciiDir, src.AsciiConfig{Path: "auto", Size: "small"}, src.OSRelease{ID: "debian"})
+	if asciiArt != "my small debian\n" {
+		t.Errorf("Expected the small file of the user, got %q", asciiArt)
+	}
+
+	asciiArt, _, err = src.LoadAsciiArt(asciiDir, src.AsciiConfig{Path: "debian"}, src.OSRelease{})
+	if err != nil || asciiArt != "my debian\n" {
+		t.Errorf("Expected a path to be read as before, got %q, %v", asciiArt, err)
+	}
+}
+
+func TestBuiltinLogos(t *testing.T) {
+	for _, id := range []string{"alpine", "arch", "debian", "fedora", "gentoo", "linux", "linuxmint", "manjaro", "nixos", "opensuse", "ubuntu"} {
+		for _, size := range []string{"large", "small"} {
+			ascii := src.AsciiConfig{Path: "auto", Size: size}
+			asciiArt, colors, err := src.LoadAsciiArt(t.TempDir(), ascii, src.OSRelease{ID: id})
+			if err != nil {
+				t.Fatalf("Expected no error for %s, got %v", id, err)
+			}
+			colored := src.StripAnsiCodes(src.ColorAsciiArt(asciiArt, colors))
+			if strings.Contains(colored, "${c") || strings.TrimSpace(colored) == "" {
+				t.Errorf("Expected the %s logo of %s to be colored, got:\n%s", size, id, colored)
+			}
+		}
+	}
+}
@@ -25,14 +25,14 @@ func TestBuildBoxMenuWithAsciiTop(t *testing.T) {
 
 	expectedMenu := "  ASCII ART\n" +
 		"\n" +
-		" ╭──────────╮\n" +
+		" ╭─────────╮\n" +
 		" │ Header   │\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │   user  │ testuser\n" +
 		" │   shell │ zsh\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │ Footer   │\n" +
-		" ╰──────────╯"
+		" ╰─────────╯"
 
 	// Strip ANSI codes and normalize strings
 	normalizedMenu := normalizeString(src.StripAnsiCodes(menu))
@@ -50,14 +50,14 @@ func TestBuildBoxMenuWithAsciiBottom(t *testing.T) {
 	asciiArt := "ASCII ART"
 	menu := src.BuildBoxMenu(items, asciiArt, config)
 
-	expectedMenu := " ╭──────────╮\n" +
+	expectedMenu := " ╭─────────╮\n" +
 		" │ Header   │\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │   user  │ testuser\n" +
 		" │   shell │ zsh\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │ Footer   │\n" +
-		" ╰──────────╯\n" +
+		" ╰─────────╯\n" +
 		"  ASCII ART\n"
 
 	// Strip ANSI codes and normalize strings
@@ -76,14 +76,14 @@ func TestBuildBoxMenuWithAsciiRight(t *testing.T) {
 	asciiArt := "ASCII ART"
 	menu := src.BuildBoxMenu(items, asciiArt, config)
 
-	expectedMenu := " ╭──────────╮\n" +
+	expectedMenu := " ╭─────────╮\n" +
 		" │ Header   │             ASCII ART\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │   user  │ testuser\n" +
 		" │   shell │ zsh\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │ Footer   │\n" +
-		" ╰──────────╯\n"
+		" ╰─────────╯\n"
 
 	// Strip ANSI codes and normalize strings
 	normalizedMenu := normalizeString(src.StripAnsiCodes(menu))
@@ -101,14 +101,14 @@ func TestBuildBoxMenuWithAsciiLeft(t *testing.T) {
 	asciiArt := "ASCII ART"
 	menu := src.BuildBoxMenu(items, asciiArt, config)
 
-	expectedMenu := "                        ╭──────────╮\n" +
+	expectedMenu := "                        ╭─────────╮\n" +
 		"          ASCII ART     │ Header   │\n" +
-		"                        ├──────────┤\n" +
+		"                        ├─────────┤\n" +
 		"                        │   user  │ testuser\n" +
 		"                        │   shell │ zsh\n" +
-		"                        ├──────────┤\n" +
+		"                        ├─────────┤\n" +
 		"                        │ Footer   │\n" +
-		"                        ╰──────────╯\n"
+		"                        ╰─────────╯\n"
 
 	// Strip ANSI codes and normalize strings
 	normalizedMenu := normalizeString(src.StripAnsiCodes(menu))
@@ -130,14 +130,14 @@ func TestBuildBoxMenuWithAsciiPadding(t *testing.T) {
 
 	expectedMenu := "\n\n" +
 		"    ASCII ART    \n\n" +
-		" ╭──────────╮\n" +
+		" ╭─────────╮\n" +
 		" │ Header   │\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │   user  │ testuser\n" +
 		" │   shell │ zsh\n" +
-		" ├──────────┤\n" +
+		" ├─────────┤\n" +
 		" │ Footer   │\n" +
-		" ╰──────────╯\n"
+		" ╰─────────╯\n"
 
 	// Strip ANSI codes and normalize strings
 	normalizedMenu := normalizeString(src.StripAnsiCodes(menu))
@@ -158,10 +158,10 @@ func TestBuildListMenuWithAsciiTop(t *testing.T) {
 	expectedMenu := "  ASCII ART\n" +
 		"\n" +
 		"Header\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"  user   testuser\n" +
 		"  shell  zsh\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"Footer\n"
 
 	// Strip ANSI codes and normalize strings
@@ -181,10 +181,10 @@ func TestBuildListMenuWithAsciiBottom(t *testing.T) {
 	menu := src.BuildListMenu(items, asciiArt, config)
 
 	expectedMenu := "Header\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"  user   testuser\n" +
 		"  shell  zsh\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"Footer\n" +
 		"  ASCII ART\n"
 
@@ -205,10 +205,10 @@ func TestBuildListMenuWithAsciiRight(t *testing.T) {
 	menu := src.BuildListMenu(items, asciiArt, config)
 
 	expectedMenu := "Header\n" +
-		"──────────                      ASCII ART\n" +
+		"─────────                      ASCII ART\n" +
 		"  user   testuser\n" +
 		"  shell  zsh\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"Footer\n"
 
 	// Strip ANSI codes and normalize strings
@@ -228,10 +228,10 @@ func TestBuildListMenuWithAsciiLeft(t *testing.T) {
 	menu := src.BuildListMenu(items, asciiArt, config)
 
 	expectedMenu := "                       Header\n" +
-		"          ASCII ART   ──────────\n" +
+		"          ASCII ART   ─────────\n" +
 		"                         user   testuser\n" +
 		"                         shell  zsh\n" +
-		"                       ──────────\n" +
+		"                       ─────────\n" +
 		"                       Footer\n"
 
 	// Strip ANSI codes and normalize strings
@@ -253,9 +253,9 @@ func TestBuildListMenuWithColumns(t *testing.T) {
 
 	expectedMenu := "  ASCII ART\n" +
 		"Header\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"  user   testuser |  shell  zsh\n" +
-		"──────────\n" +
+		"─────────\n" +
 		"Footer\n"
 
 	// Strip ANSI codes and normalize strings
@@ -273,55 +273,32 @@ func TestBuildListMenuWithColumns(t *testing.T) {
 func GetConfigWithAscii(position string) src.Config {
 	return src.Config{
 		Items: []src.ConfigItem{
-			{Text: "user", Keyword: "user", Icon: "", TextColor: "", ValueColor: "", IconColor: ""},
-			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "", ValueColor: "", IconColor: ""},
+			{Text: "user", Keyword: "user", Icon: "", TextColor: "", ValueColor: nil, IconColor: ""},
+			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "", ValueColor: nil, IconColor: ""},
 		},
-		Ascii: struct {
-			Path              string `json:"path"`
-			Colors            string `json:"colors"`
-			Enabled           bool   `json:"enabled"`
-			HorizontalPadding int    `json:"horizontal_padding"`
-			VerticalPadding   int    `json:"vertical_padding"`
-			Position          string `json:"position"`
-		}{
+		Ascii: src.AsciiConfig{
 			Path:              "ascii/gysmo",
-			Colors:            "",
+			Colors:            nil,
 			Enabled:           true,
 			HorizontalPadding: 2,
 			VerticalPadding:   1,
 			Position:          position,
 		},
-		Header: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Header: src.HeaderConfig{
 			Text:      "Header",
 			TextColor: "",
 			LineColor: "",
 			Line:      true,
 			Enabled:   true,
 		},
-		Footer: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Footer: src.FooterConfig{
 			Text:      "Footer",
 			TextColor: "",
 			LineColor: "",
 			Line:      true,
 			Enabled:   true,
 		},
-		General: struct {
-			MenuType    string `json:"menu_type"`
-			Columns     bool   `json:"columns"`
-			MenuPadding int    `json:"menu_padding"`
-		}{
+		General: src.GeneralConfig{
 			MenuType:    "box",
 			Columns:     false,
 			MenuPadding: 2,
@@ -332,55 +309,32 @@ func GetConfigWithAscii(position string) src.Config {
 func GetConfigWithHeader() src.Config {
 	return src.Config{
 		Items: []src.ConfigItem{
-			{Text: "user", Keyword: "user", Icon: "", TextColor: "red", ValueColor: "green", IconColor: "blue"},
-			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "yellow", ValueColor: "blue", IconColor: "cyan"},
+			{Text: "user", Keyword: "user", Icon: "", TextColor: "red", ValueColor: src.ColorRules{{Color: "green"}}, IconColor: "blue"},
+			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "yellow", ValueColor: src.ColorRules{{Color: "blue"}}, IconColor: "cyan"},
 		},
-		Ascii: struct {
-			Path              string `json:"path"`
-			Colors            string `json:"colors"`
-			Enabled           bool   `json:"enabled"`
-			HorizontalPadding int    `json:"horizontal_padding"`
-			VerticalPadding   int    `json:"vertical_padding"`
-			Position          string `json:"position"`
-		}{
+		Ascii: src.AsciiConfig{
 			Path:              "ascii/gysmo",
-			Colors:            "red",
+			Colors:            src.AsciiColors{"red"},
 			Enabled:           true,
 			HorizontalPadding: 2,
 			VerticalPadding:   1,
 			Position:          "top",
 		},
-		Header: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Header: src.HeaderConfig{
 			Text:      "Header",
 			TextColor: "white",
 			LineColor: "red",
 			Line:      true,
 			Enabled:   true,
 		},
-		Footer: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Footer: src.FooterConfig{
 			Text:      "Footer",
 			TextColor: "white",
 			LineColor: "red",
 			Line:      true,
 			Enabled:   true,
 		},
-		General: struct {
-			MenuType    string `json:"menu_type"`
-			Columns     bool   `json:"columns"`
-			MenuPadding int    `json:"menu_padding"`
-		}{
+		General: src.GeneralConfig{
 			MenuType:    "box",
 			Columns:     false,
 			MenuPadding: 0,
@@ -391,55 +345,32 @@ func GetConfigWithHeader() src.Config {
 func GetConfigWithFooter() src.Config {
 	return src.Config{
 		Items: []src.ConfigItem{
-			{Text: "user", Keyword: "user", Icon: "", TextColor: "red", ValueColor: "green", IconColor: "blue"},
-			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "yellow", ValueColor: "blue", IconColor: "cyan"},
+			{Text: "user", Keyword: "user", Icon: "", TextColor: "red", ValueColor: src.ColorRules{{Color: "green"}}, IconColor: "blue"},
+			{Text: "shell", Keyword: "shell", Icon: "", TextColor: "yellow", ValueColor: src.ColorRules{{Color: "blue"}}, IconColor: "cyan"},
 		},
-		Ascii: struct {
-			Path              string `json:"path"`
-			Colors            string `json:"colors"`
-			Enabled           bool   `json:"enabled"`
-			HorizontalPadding int    `json:"horizontal_padding"`
-			VerticalPadding   int    `json:"vertical_padding"`
-			Position          string `json:"position"`
-		}{
+		Ascii: src.AsciiConfig{
 			Path:              "ascii/gysmo",
-			Colors:            "red",
+			Colors:            src.AsciiColors{"red"},
 			Enabled:           true,
 			HorizontalPadding: 2,
 			VerticalPadding:   1,
 			Position:          "top",
 		},
-		Header: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Header: src.HeaderConfig{
 			Text:      "Header",
 			TextColor: "white",
 			LineColor: "red",
 			Line:      true,
 			Enabled:   true,
 		},
-		Footer: struct {
-			Text      string `json:"text"`
-			TextColor string `json:"text_color"`
-			LineColor string `json:"line_color"`
-			Line      bool   `json:"line"`
-			Enabled   bool   `json:"enabled"`
-		}{
+		Footer: src.FooterConfig{
 			Text:      "Footer",
 			TextColor: "white",
 			LineColor: "red",
 			Line:      true,
 			Enabled:   true,
 		},
-		General: struct {
-			MenuType    string `json:"menu_type"`
-			Columns     bool   `json:"columns"`
-			MenuPadding int    `json:"menu_padding"`
-		}{
+		General: src.GeneralConfig{
 			MenuType:    "box",
 			Columns:     false,
 			MenuPadding: 0,
@@ -447,15 +378,15 @@ func GetConfigWithFooter() src.Config {
 	}
 }
 
-func GetTestItems() map[string]string {
-	return map[string]string{
+func GetTestItems() map[string]src.Value {
+	return src.TextValues(map[string]string{
 		"user":  "testuser",
 		"shell": "zsh",
-	}
+	})
 }
 
-func GetTestItemsWithMoreThan10Items() map[string]string {
-	items := map[string]string{
+func GetTestItemsWithMoreThan10Items() map[string]src.Value {
+	items := src.TextValues(map[string]string{
 		"user":   "testuser",
 		"shell":  "zsh",
 		"item1":  "value1",
@@ -469,22 +400,22 @@ func GetTestItemsWithMoreThan10Items() map[string]string {
 		"item9":  "value9",
 		"item10": "value10",
 		"item11": "value11",
-	}
+	})
 	return items
 }
 
-func GetTestItemsWithLongText() map[string]string {
-	return map[string]string{
+func GetTestItemsWithLongText() map[string]src.Value {
+	return src.TextValues(map[string]string{
 		"user":     "testuser",
 		"shell":    "zsh",
 		"longtext": "This is a very long text item that should be tested for proper handling in the menu",
-	}
+	})
 }
 
-func GetTestItemsWithLongValue() map[string]string {
-	return map[string]string{
+func GetTestItemsWithLongValue() map[string]src.Value {
+	return src.TextValues(map[string]string{
 		"user":      "testuser",
 		"shell":     "zsh",
 		"longvalue": "This is a very long value for the item that should be tested for proper handling in the menu",
-	}
+	})
 }
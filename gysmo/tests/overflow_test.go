@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncateToWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"grosheth", 10, "grosheth"},
+		{"grosheth", 5, "gros…"},
+		{"漢字漢字", 5, "漢字…"},
+		{"Cafe\u0301 noir", 5, "Cafe\u0301…"},
+	}
+
+	for _, test := range tests {
+		if result := src.TruncateToWidth(test.text, test.width); result != test.expected {
+			t.Errorf("For %s in %d cells, expected %q, but got %q", test.text, test.width, test.expected, result)
+		}
+	}
+}
+
+func TestWrapToWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		{"Intel Corporation Alder Lake", 12, []string{"Intel", "Corporation", "Alder Lake"}},
+		{"https://bugs.launchpad.net/ubuntu", 12, []string{"https://bugs", ".launchpad.n", "et/ubuntu"}},
+		{"漢字漢字漢字", 5, []string{"漢字", "漢字", "漢字"}},
+		{"short", 12, []string{"short"}},
+		{"", 12, []string{""}},
+	}
+
+	for _, test := range tests {
+		if result := src.WrapToWidth(test.text, test.width); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("For %s in %d cells, expected %q, but got %q", test.text, test.width, test.expected, result)
+		}
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	originalSize := src.TerminalSize
+	originalLookupEnv := src.LookupEnv
+	defer func() {
+		src.TerminalSize = originalSize
+		src.LookupEnv = originalLookupEnv
+	}()
+
+	columns := 0
+	src.TerminalSize = func() (int, int) { return columns, 0 }
+	src.LookupEnv = func(key string) (string, bool) {
+		if key == "COLUMNS" {
+			return "100", true
+		}
+		return "", false
+	}
+
+	if width := src.TerminalWidth(0, 0); width != 100 {
+		t.Errorf("Expected $COLUMNS without a terminal, but got %d", width)
+	}
+	columns = 120
+	if width := src.TerminalWidth(0, 0); width != 120 {
+		t.Errorf("Expected the terminal width, but got %d", width)
+	}
+	if width := src.TerminalWidth(0, 90); width != 90 {
+		t.Errorf("Expected general.width over the terminal, but got %d", width)
+	}
+	if width := src.TerminalWidth(60, 90); width != 60 {
+		t.Errorf("Expected the --width flag over everything, but got %d", width)
+	}
+}
+
+func getOverflowConfig(position string) src.Config {
+	config := GetConfigWithAscii(position)
+	config.General.Width = 40
+	config.Items = []src.ConfigItem{
+		{Text: "Bugs", Value: "https://bugs.launchpad.net/ubuntu/+filebug", Overflow: "wrap"},
+		{Text: "GPU", Value: "Intel Corporation Alder Lake-P GT2 [Iris Xe Graphics]"},
+		{Text: "Hidden", Value: "a value that does not fit in the terminal", Overflow: "hide"},
+		{Text: "User", Value: "grosheth", Overflow: "hide"},
+	}
+	return config
+}
+
+func assertFitsWidth(t *testing.T, menu string, width int) {
+	t.Helper()
+	for _, line := range strings.Split(src.StripAnsiCodes(menu), "\n") {
+		if src.DisplayWidth(strings.TrimRight(line, " ")) > width {
+			t.Errorf("Expected lines to fit in %d cells, but %q is %d:\n%s", width, line, src.DisplayWidth(line), menu)
+		}
+	}
+}
+
+func TestBuildBoxMenuWithOverflow(t *testing.T) {
+	config := getOverflowConfig("top")
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(nil, "ASCII ART", config))
+
+	assertFitsWidth(t, menu, 40)
+	for _, expected := range []string{
+		"│ Bugs   │ https://bugs.launchpad.net/\n",
+		"│        │ ubuntu/+filebug\n",
+		"│ GPU    │ Intel Corporation Alder La…\n",
+		"│ User   │ grosheth\n",
+	} {
+		if !strings.Contains(menu, expected) {
+			t.Errorf("Expected the menu to contain %q, got:\n%s", expected, menu)
+		}
+	}
+	if strings.Contains(menu, "Hidden") {
+		t.Errorf("Expected the item that does not fit to be hidden, got:\n%s", menu)
+	}
+	assertAligned(t, strings.Replace(menu, "ASCII ART", "", 1))
+}
+
+func TestBuildMenusWithOverflowBesideAscii(t *testing.T) {
+	for _, position := range []string{"left", "right"} {
+		config := getOverflowConfig(position)
+		assertFitsWidth(t, src.BuildBoxMenu(nil, "ASCII ART", config), 40)
+		assertFitsWidth(t, src.BuildListMenu(nil, "ASCII ART", config), 40)
+	}
+}
+
+func TestBuildListMenuWithOverflow(t *testing.T) {
+	config := getOverflowConfig("top")
+	menu := src.StripAnsiCodes(src.BuildListMenu(nil, "", config))
+
+	assertFitsWidth(t, menu, 40)
+	if !strings.Contains(menu, "Bugs    https://bugs.launchpad.net/ub\n           untu/+filebug\n") {
+		t.Errorf("Expected the value to continue under itself, got:\n%s", menu)
+	}
+	if strings.Contains(menu, "Hidden") {
+		t.Errorf("Expected the item that does not fit to be hidden, got:\n%s", menu)
+	}
+}
+
+func TestBuildListMenuWithOverflowInColumns(t *testing.T) {
+	config := getOverflowConfig("top")
+	config.General.Width = 60
+	config.General.Columns = true
+	menu := src.StripAnsiCodes(src.BuildListMenu(nil, "", config))
+
+	assertFitsWidth(t, menu, 60)
+	if !strings.Contains(menu, "Bugs    https://bugs.laun…") {
+		t.Errorf("Expected wrapped values to be truncated in columns, got:\n%s", menu)
+	}
+}
+
+func TestBuildBoxMenuWithoutWidth(t *testing.T) {
+	config := getOverflowConfig("top")
+	config.General.Width = 0
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(nil, "", config))
+	if !strings.Contains(menu, "Intel Corporation Alder Lake-P GT2 [Iris Xe Graphics]") || !strings.Contains(menu, "Hidden") {
+		t.Errorf("Expected nothing to be cut when the width is unknown, got:\n%s", menu)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"errors"
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func mockPaletteFiles(t *testing.T, files map[string]string) {
+	originalReadFile := src.ReadFile
+	t.Cleanup(func() {
+		src.ReadFile = originalReadFile
+		src.LoadPaletteSources()
+	})
+	src.ReadFile = func(name string) ([]byte, error) {
+		for suffix, content := range files {
+			if strings.HasSuffix(name, suffix) {
+				return []byte(content), nil
+			}
+		}
+		return nil, errors.New("file not found")
+	}
+	src.LoadPaletteSources()
+}
+
+func TestPaletteSources(t *testing.T) {
+	mockPaletteFiles(t, map[string]string{
+		"/etc/os-release": "NAME=\"Arch Linux\"\nANSI_COLOR=\"0;38;2;23;147;209\"\n",
+		"/.cache/wal/colors.json": `{
+			"special": {"background": "#0f0f17", "foreground": "#c3c3c5", "cursor": "#c3c3c5"},
+			"colors": {"color0": "#0f0f17", "color4": "#5a6b9e"}
+		}`,
+		"/.Xresources": "! comment\n*.color1: #cc6666\n*foreground:   #c5c8c6\nURxvt.color2: #00ff00\n*.cursorColor: #ffffff\n",
+	})
+
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{"distro", "\033[38;2;23;147;209m"},
+		{"bold wal:color4", "\033[1;38;2;90;107;158m"},
+		{"wal:foreground on wal:background", "\033[38;2;195;195;197;48;2;15;15;23m"},
+		{"xrdb:color1", "\033[38;2;204;102;102m"},
+		{"xrdb:foreground", "\033[38;2;197;200;198m"},
+		{"xrdb:cursor", "\033[38;2;255;255;255m"},
+		{"xrdb:color2", "\033[39m"},
+		{"wal:color9", "\033[39m"},
+	}
+
+	for _, test := range tests {
+		if code := src.GetColorCode(test.style); code != test.expected {
+			t.Errorf("For style %q, expected %q, but got %q", test.style, test.expected, code)
+		}
+	}
+}
+
+func TestPaletteSourcesMissing(t *testing.T) {
+	mockPaletteFiles(t, map[string]string{"/etc/os-release": "ANSI_COLOR=\"1;34\"\n"})
+
+	if code := src.GetColorCode("distro"); code != src.Blue {
+		t.Errorf("Expected the blue of ANSI_COLOR without its attribute, got %q", code)
+	}
+	if code := src.GetColorCode("wal:color1"); code != "\033[39m" {
+		t.Errorf("Expected the default color without pywal, got %q", code)
+	}
+	if _, err := src.ParseStyle("wal:color16"); err == nil {
+		t.Errorf("Expected an error for an unknown palette color")
+	}
+}
+
+func TestTerminalPaletteIndexes(t *testing.T) {
+	tests := map[string]string{
+		"4":    "\033[34m",
+		"9":    "\033[91m",
+		"on 1": "\033[41m",
+		"16":   "\033[38;5;16m",
+	}
+	for style, expected := range tests {
+		if code := src.GetColorCode(style); code != expected {
+			t.Errorf("For style %q, expected %q, but got %q", style, expected, code)
+		}
+	}
+}
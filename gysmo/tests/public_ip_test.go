@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"fmt"
+	"gysmo/gysmo/src"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newPublicIPServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "203.0.113.7\n")
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"address": "2001:db8::1"}}`)
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html><body>Rate limited</body></html>")
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "203.0.113.9", http.StatusServiceUnavailable)
+	})
+	return httptest.NewServer(mux)
+}
+
+func setupPublicIPTest(t *testing.T, online bool) *httptest.Server {
+	t.Setenv("HOME", t.TempDir())
+	server := newPublicIPServer()
+	t.Cleanup(server.Close)
+
+	originalGet := src.HttpGet
+	originalRoute := src.HasDefaultRoute
+	src.HttpGet = server.Client().Get
+	src.HasDefaultRoute = func() bool { return online }
+	t.Cleanup(func() {
+		src.HttpGet = originalGet
+		src.HasDefaultRoute = originalRoute
+	})
+	return server
+}
+
+func TestGetPublicIPProviders(t *testing.T) {
+	server := setupPublicIPTest(t, true)
+
+	tests := []struct {
+		name      string
+		providers []src.PublicIPProvider
+		expected  string
+	}{
+		{"text", []src.PublicIPProvider{{URL: server.URL + "/text"}}, "203.0.113.7"},
+		{"json", []src.PublicIPProvider{{URL: server.URL + "/json", Format: "json", Field: "data.address"}}, "2001:db8::1"},
+		{"html is rejected", []src.PublicIPProvider{{URL: server.URL + "/html"}}, "Not Found"},
+		{"error status is rejected", []src.PublicIPProvider{{URL: server.URL + "/error"}}, "Not Found"},
+		{"fallback", []src.PublicIPProvider{{URL: server.URL + "/html"}, {URL: server.URL + "/error"}, {URL: server.URL + "/text"}}, "203.0.113.7"},
+	}
+
+	for _, test := range tests {
+		result := src.GetPublicIP(src.PublicIPConfig{Providers: test.providers, CacheTTL: -1}).Display
+		if result != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestGetPublicIPCache(t *testing.T) {
+	server := setupPublicIPTest(t, true)
+
+	config := src.PublicIPConfig{Providers: []src.PublicIPProvider{{URL: server.URL + "/text"}}}
+	if result := src.GetPublicIP(config).Display; result != "203.0.113.7" {
+		t.Fatalf("Expected 203.0.113.7, but got %s", result)
+	}
+
+	// A cached value must be served without reaching the provider.
+	config.Providers = []src.PublicIPProvider{{URL: server.URL + "/html"}}
+	if result := src.GetPublicIP(config).Display; result != "203.0.113.7" {
+		t.Errorf("Expected cached 203.0.113.7, but got %s", result)
+	}
+}
+
+func TestGetPublicIPOffline(t *testing.T) {
+	server := setupPublicIPTest(t, false)
+
+	config := src.PublicIPConfig{Providers: []src.PublicIPProvider{{URL: server.URL + "/text"}}}
+	if result := src.GetPublicIP(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found when offline, but got %s", result)
+	}
+
+	// Stale entries are still better than nothing when there is no route out.
+	dataDir := filepath.Join(os.Getenv("HOME"), ".config", "gysmo", "data")
+	os.MkdirAll(dataDir, 0755)
+	stale := `{"public ip": {"value": "198.51.100.4", "updated_at": "2020-01-01T00:00:00Z"}}`
+	if err := os.WriteFile(filepath.Join(dataDir, "cache.json"), []byte(stale), 0644); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result := src.GetPublicIP(config).Display; result != "198.51.100.4" {
+		t.Errorf("Expected last known 198.51.100.4 when offline, but got %s", result)
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{"", ""},
+		{"Red", "\033[31m"},
+		{"bright_blue", "\033[94m"},
+		{"gray", "\033[90m"},
+		{"208", "\033[38;5;208m"},
+		{"#f80", "\033[38;2;255;136;0m"},
+		{"rgb(255, 136, 0)", "\033[38;2;255;136;0m"},
+		{"bold underline", "\033[1;4m"},
+		{"on blue", "\033[44m"},
+		{"bold italic #ff8800 on #202020", "\033[1;3;38;2;255;136;0;48;2;32;32;32m"},
+		{"strikethrough 240 on bright_black", "\033[9;38;5;240;100m"},
+	}
+
+	for _, test := range tests {
+		style, err := src.ParseStyle(test.style)
+		if err != nil {
+			t.Errorf("For style %q, expected no error, got %v", test.style, err)
+			continue
+		}
+		if style.Code() != test.expected {
+			t.Errorf("For style %q, expected %q, but got %q", test.style, test.expected, style.Code())
+		}
+	}
+}
+
+func TestParseStyleInvalid(t *testing.T) {
+	for _, style := range []string{"unknown", "256", "#12345", "rgb(300, 0, 0)", "bold on", "bright_default"} {
+		if _, err := src.ParseStyle(style); err == nil {
+			t.Errorf("Expected an error for style %q", style)
+		}
+	}
+}
+
+func TestGetColorCodeWithStyle(t *testing.T) {
+	if code := src.GetColorCode("bold green"); code != "\033[1;32m" {
+		t.Errorf("Expected bold green, got %q", code)
+	}
+	if code := src.GetColorCode("bold nope"); code != src.Reset {
+		t.Errorf("Expected an invalid style to reset the color, got %q", code)
+	}
+	if code := src.GetBackgroundColorCode("bright_red"); code != "\033[101m" {
+		t.Errorf("Expected a bright red background, got %q", code)
+	}
+}
+
+func TestValidateStyles(t *testing.T) {
+	config := src.Config{Items: []src.ConfigItem{{Text: "ok", TextColor: "bold cyan", IconColor: "orange"}}}
+	err := src.ValidateStyles(config)
+	if err == nil || !strings.Contains(err.Error(), "items.0.icon_color") {
+		t.Errorf("Expected an error for the icon color, got %v", err)
+	}
+
+	config.Items[0].IconColor = "italic 33 on #101010"
+	config.Header.TextColor = "underline bright_purple"
+	if err := src.ValidateStyles(config); err != nil {
+		t.Errorf("Expected valid styles, got %v", err)
+	}
+
+	config.Footer.LineColor = "rgb(1, 2)"
+	if err := src.ValidateStyles(config); err == nil || !strings.Contains(err.Error(), "footer.line_color") {
+		t.Errorf("Expected an error for the footer line color, got %v", err)
+	}
+}
@@ -2,7 +2,7 @@ package tests
 
 import (
 	"gysmo/gysmo/src"
-	"reflect"
+	"net/http"
 	"testing"
 )
 
@@ -10,40 +10,40 @@ import (
 func TestGetCPUInfo(t *testing.T) {
 	cpuInfo := src.GetCPUInfo()
 
-	if reflect.TypeOf(cpuInfo).Kind() != reflect.String {
-		t.Errorf("Expected CPU info to be of type string, got '%T'", cpuInfo)
+	if cpuInfo.Numeric || cpuInfo.Display == "" {
+		t.Errorf("Expected CPU info to be text, got '%+v'", cpuInfo)
 	}
 }
 
 // Test GetRAMUsage function with tolerance range
 func TestGetRAMUsage(t *testing.T) {
 	ramUsage := src.GetRAMUsage()
-	if reflect.TypeOf(ramUsage).Kind() != reflect.String {
-		t.Errorf("Expected RAM Usage to be of type string, got '%T'", ramUsage)
+	if ramUsage.Numeric && ramUsage.Unit != "%" {
+		t.Errorf("Expected RAM Usage to be a percentage, got '%+v'", ramUsage)
 	}
 }
 
 // Test GetRAMInfo function with possible sizes
 func TestGetRAMInfo(t *testing.T) {
 	ramInfo := src.GetRAMInfo()
-	if reflect.TypeOf(ramInfo).Kind() != reflect.String {
-		t.Errorf("Expected RAM info to be of type string, got '%T'", ramInfo)
+	if ramInfo.Numeric && ramInfo.Unit != "B" {
+		t.Errorf("Expected RAM info to be in bytes, got '%+v'", ramInfo)
 	}
 }
 
 // Test GetDriveInfo function
 func TestGetDriveInfo(t *testing.T) {
 	driveInfo := src.GetDriveInfo()
-	if reflect.TypeOf(driveInfo).Kind() != reflect.String {
-		t.Errorf("Expected Drive Info to be of type string, got '%T'", driveInfo)
+	if driveInfo.Numeric || driveInfo.Display == "" {
+		t.Errorf("Expected Drive Info to be text, got '%+v'", driveInfo)
 	}
 }
 
 // Test GetDriveUsage function with tolerance range
 func TestGetDriveUsage(t *testing.T) {
 	driveUsage := src.GetDriveUsage()
-	if reflect.TypeOf(driveUsage).Kind() != reflect.String {
-		t.Errorf("Expected Drive Usage to be of type string, got '%T'", driveUsage)
+	if driveUsage.Numeric && driveUsage.Unit != "%" {
+		t.Errorf("Expected Drive Usage to be a percentage, got '%+v'", driveUsage)
 	}
 }
 
@@ -51,15 +51,15 @@ func TestGetDriveUsage(t *testing.T) {
 func TestGetCPUUsage(t *testing.T) {
 	cpuUsage := src.GetCPUUsage()
 
-	if reflect.TypeOf(cpuUsage).Kind() != reflect.String {
-		t.Errorf("Expected CPU Usage to be of type string, got '%T'", cpuUsage)
+	if cpuUsage.Numeric && cpuUsage.Unit != "%" {
+		t.Errorf("Expected CPU Usage to be a percentage, got '%+v'", cpuUsage)
 	}
 }
 
 func TestGetGPUInfo(t *testing.T) {
 	gpuInfo := src.GetGPUInfo()
-	if reflect.TypeOf(gpuInfo).Kind() != reflect.String {
-		t.Errorf("Expected GPU info to be of type string, got '%T'", gpuInfo)
+	if gpuInfo.Numeric || gpuInfo.Display == "" {
+		t.Errorf("Expected GPU info to be text, got '%+v'", gpuInfo)
 	}
 }
 
@@ -67,8 +67,8 @@ func TestGetGPUInfo(t *testing.T) {
 func TestGetGPUUsage(t *testing.T) {
 	gpuUsage := src.GetGPUUsage()
 
-	if reflect.TypeOf(gpuUsage).Kind() != reflect.String {
-		t.Errorf("Expected GPU Usage to be of type string, got '%T'", gpuUsage)
+	if gpuUsage.Numeric && gpuUsage.Unit != "%" {
+		t.Errorf("Expected GPU Usage to be a percentage, got '%+v'", gpuUsage)
 	}
 }
 
@@ -76,8 +76,8 @@ func TestGetGPUUsage(t *testing.T) {
 func TestGetUptime(t *testing.T) {
 	uptime := src.GetUptime()
 
-	if reflect.TypeOf(uptime).Kind() != reflect.String {
-		t.Errorf("Expected Uptime to be of type string, got '%T'", uptime)
+	if uptime.Numeric || uptime.Display == "" {
+		t.Errorf("Expected Uptime to be text, got '%+v'", uptime)
 	}
 }
 
@@ -85,15 +85,19 @@ func TestGetUptime(t *testing.T) {
 func TestGetResolution(t *testing.T) {
 	resolution := src.GetResolution()
 
-	if reflect.TypeOf(resolution).Kind() != reflect.String {
-		t.Errorf("Expected resolution to be of type string, got '%T'", resolution)
+	if resolution.Numeric || resolution.Display == "" {
+		t.Errorf("Expected resolution to be text, got '%+v'", resolution)
 	}
 }
 
 // Test GetPublicIP function
 func TestGetPublicIP(t *testing.T) {
-	publicIP := src.GetPublicIP()
-	if reflect.TypeOf(publicIP).Kind() != reflect.String {
-		t.Errorf("Expected public IP to be of type string, got '%T'", publicIP)
+	server := setupPublicIPTest(t, true)
+	// The default providers are answered by the stand-in instead of the network
+	src.HttpGet = func(string) (*http.Response, error) { return server.Client().Get(server.URL + "/text") }
+
+	publicIP := src.GetPublicIP(src.PublicIPConfig{}).Display
+	if publicIP != "203.0.113.7" {
+		t.Errorf("Expected the public IP from the first default provider, got '%s'", publicIP)
 	}
 }
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	items := map[string]string{
+		"user":     "grosheth",
+		"hostname": "nixos",
+		"ram":      "16 GB",
+		"ram %":    "42.00%",
+		"gpu":      "Not Found",
+		"bytes":    "1610612736",
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"{{.user}}@{{.hostname}}", "grosheth@nixos"},
+		{`{{.ram}} ({{."ram %"}})`, "16 GB (42.00%)"},
+		{`{{index . "ram %"}}`, "42.00%"},
+		{"{{.hostname | upper}}", "NIXOS"},
+		{"{{.hostname | upper | lower}}", "nixos"},
+		{"{{.user | truncate 5}}", "gros…"},
+		{`{{.gpu | default "none"}}`, "none"},
+		{`{{.missing | default "none"}}`, "none"},
+		{"{{.bytes | humanize}}", "1.5 GiB"},
+		{"plain text", "plain text"},
+		{"{{.user", "{{.user"},
+	}
+
+	for _, test := range tests {
+		if result := src.RenderTemplate(test.text, items); result != test.expected {
+			t.Errorf("For %s, expected %q, but got %q", test.text, test.expected, result)
+		}
+	}
+}
+
+func TestTemplateKeywords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"{{.user}}@{{.hostname}}", []string{"user", "hostname"}},
+		{`{{.ram}} / {{."ram %"}}`, []string{"ram", "ram %"}},
+		{`{{if .gpu}}{{.gpu | upper}}{{else}}{{index . "cpu %"}}{{end}}`, []string{"gpu", "gpu", "cpu %"}},
+		{`{{with .gpu}}{{$.user}} {{index $ "ram %"}}{{end}}`, []string{"gpu", "user", "ram %"}},
+		{`{{(.user).name}} {{($.hostname).name}}`, []string{"user", "hostname"}},
+		{"no template", nil},
+	}
+
+	for _, test := range tests {
+		result := src.TemplateKeywords(test.text)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("For %s, expected %v, but got %v", test.text, test.expected, result)
+		}
+	}
+}
+
+func TestItemKeywordsIncludesTemplates(t *testing.T) {
+	configItems := []src.ConfigItem{
+		{Text: "User", Keyword: "user"},
+		{Text: "{{.os_name}}", Value: "{{.user}}@{{.hostname}}"},
+	}
+	expected := []string{"user", "os_name", "hostname"}
+	if result := src.ItemKeywords(configItems); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	config := src.Config{Items: []src.ConfigItem{{Text: "ok", Value: "{{.user"}}}
+	if err := src.ValidateTemplates(config); err == nil {
+		t.Errorf("Expected an error for an unclosed template")
+	}
+}
+
+func TestBuildBoxMenuWithTemplates(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Header.Enabled = false
+	config.Footer.Enabled = false
+	config.Items = []src.ConfigItem{{Text: "{{.user | upper}}", Value: "{{.user}}@{{.hostname}}"}}
+	items := src.TextValues(map[string]string{"user": "root", "hostname": "nixos"})
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	if !strings.Contains(menu, "│ ROOT │ root@nixos") {
+		t.Errorf("Expected rendered text and value, got:\n%s", menu)
+	}
+	if !strings.Contains(menu, "╭──────╮") {
+		t.Errorf("Expected the border to be measured on the rendered text, got:\n%s", menu)
+	}
+}
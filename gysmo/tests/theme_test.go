@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"errors"
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func mockThemeFile(t *testing.T, content string) {
+	originalReadFile := src.ReadFile
+	t.Cleanup(func() { src.ReadFile = originalReadFile })
+	src.ReadFile = func(name string) ([]byte, error) {
+		if !strings.HasSuffix(name, "/themes/mine.json") {
+			return nil, errors.New("file not found")
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestLoadBuiltinTheme(t *testing.T) {
+	defer src.SetTheme(mustLoadTheme(t, ""))
+
+	for _, name := range []string{"catppuccin-mocha", "gruvbox", "nord", "dracula"} {
+		theme := mustLoadTheme(t, name)
+		for _, slot := range src.ThemeSlots {
+			if theme[slot] == "" {
+				t.Errorf("Expected theme %s to set %s", name, slot)
+			}
+		}
+	}
+
+	src.SetTheme(mustLoadTheme(t, "nord"))
+	if code := src.GetColorCode("bold accent on c8"); code != "\033[1;38;2;136;192;208;48;2;76;86;106m" {
+		t.Errorf("Expected the colors of the nord theme, got %q", code)
+	}
+}
+
+func TestDefaultThemeSlots(t *testing.T) {
+	src.SetTheme(mustLoadTheme(t, ""))
+	if code := src.GetColorCode("accent"); code != src.Blue {
+		t.Errorf("Expected accent to be blue without a theme, got %q", code)
+	}
+	if code := src.GetColorCode("c1"); code != src.Red {
+		t.Errorf("Expected c1 to be red without a theme, got %q", code)
+	}
+}
+
+func TestLoadThemeFile(t *testing.T) {
+	mockThemeFile(t, `{"accent": "#FF8800", "c2": "bright_green"}`)
+
+	theme := mustLoadTheme(t, "mine")
+	if theme["accent"] != "#ff8800" || theme["c2"] != "bright_green" {
+		t.Errorf("Expected the colors of the file, got %v", theme)
+	}
+	if theme["muted"] != "gray" {
+		t.Errorf("Expected missing slots to keep their default color, got %q", theme["muted"])
+	}
+}
+
+func TestLoadThemeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"missing", "", "could not be read"},
+		{"mine", `{"primary": "red"}`, "unknown slot"},
+		{"mine", `{"accent": "accent"}`, "invalid color for accent"},
+		{"mine", `{"accent": `, "invalid theme"},
+		{"../../secrets", `{}`, "not a valid name"},
+		{"nested/mine", `{}`, "not a valid name"},
+		{`..\mine`, `{}`, "not a valid name"},
+		{"..", `{}`, "not a valid name"},
+	}
+
+	for _, test := range tests {
+		mockThemeFile(t, test.content)
+		_, err := src.LoadTheme(test.name)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("For theme %s %s, expected an error containing %q, got %v", test.name, test.content, test.err, err)
+		}
+	}
+}
+
+func mustLoadTheme(t *testing.T, name string) src.Theme {
+	t.Helper()
+	theme, err := src.LoadTheme(name)
+	if err != nil {
+		t.Fatalf("Expected theme %q to load, got %v", name, err)
+	}
+	return theme
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"gysmo/gysmo/src"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +31,26 @@ func TestGetColorCode(t *testing.T) {
 	}
 }
 
+func TestGetBackgroundColorCode(t *testing.T) {
+	tests := []struct {
+		color    string
+		expected string
+	}{
+		{"red", "\033[41m"},
+		{"white", "\033[47m"},
+		{"#FF5733", "\033[48;2;255;87;51m"},
+		{"unknown", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		result := src.GetBackgroundColorCode(test.color)
+		if result != test.expected {
+			t.Errorf("For color %s, expected %q, but got %q", test.color, test.expected, result)
+		}
+	}
+}
+
 func TestGetEnvVar(t *testing.T) {
 	os.Setenv("TEST_ENV_VAR", "test_value")
 	defer os.Unsetenv("TEST_ENV_VAR")
@@ -94,3 +115,13 @@ func TestCharsToString(t *testing.T) {
 		t.Errorf("Expected %s, but got %s", expected, result)
 	}
 }
+
+func TestReadResponse(t *testing.T) {
+	body, err := src.ReadResponse(strings.NewReader(strings.Repeat("x", 1<<20)))
+	if err != nil || len(body) != 1<<20 {
+		t.Errorf("Expected a body of 1 MiB to be read, got %d bytes and %v", len(body), err)
+	}
+	if _, err := src.ReadResponse(strings.NewReader(strings.Repeat("x", 1<<20+1))); err == nil {
+		t.Errorf("Expected an error for a body larger than 1 MiB")
+	}
+}
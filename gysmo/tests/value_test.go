@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		text    string
+		numeric bool
+		raw     float64
+		unit    string
+	}{
+		{"42", true, 42, ""},
+		{"37%", true, 37, "%"},
+		{"-3.5°C", true, -3.5, "°C"},
+		{"12 kWh", true, 12, "kWh"},
+		{"1.2.3", false, 0, ""},
+		{"2026-10-18", false, 0, ""},
+		{"3 little pigs", false, 0, ""},
+		{"nixos", false, 0, ""},
+	}
+
+	for _, test := range tests {
+		value := src.ParseValue(test.text)
+		if value.Numeric != test.numeric || value.Raw != test.raw || value.Unit != test.unit {
+			t.Errorf("For %s, expected numeric=%v raw=%v unit=%q, but got %+v", test.text, test.numeric, test.raw, test.unit, value)
+		}
+		if value.Display != test.text {
+			t.Errorf("For %s, expected the display to be kept, but got %s", test.text, value.Display)
+		}
+	}
+}
+
+func TestValueFormat(t *testing.T) {
+	zero := 0
+	one := 1
+	tests := []struct {
+		name     string
+		value    src.Value
+		format   *src.ValueFormat
+		expected string
+	}{
+		{"no format", src.PercentValue(42.123), nil, "42.12%"},
+		{"percent decimals", src.PercentValue(42.123), &src.ValueFormat{Decimals: &zero}, "42%"},
+		{"percent space", src.PercentValue(42.123), &src.ValueFormat{Percent: "space", Decimals: &one}, "42.1 %"},
+		{"percent fraction", src.PercentValue(42), &src.ValueFormat{Percent: "fraction"}, "0.4200"},
+		{"percent none", src.PercentValue(42), &src.ValueFormat{Percent: "none", Decimals: &zero}, "42"},
+		{"bytes binary", src.BytesValue(16*1024*1024*1024, "16 GB"), &src.ValueFormat{}, "16.0 GiB"},
+		{"bytes decimal", src.BytesValue(16*1024*1024*1024, "16 GB"), &src.ValueFormat{Units: "decimal", Decimals: &zero}, "17 GB"},
+		{"count", src.CountValue(121), &src.ValueFormat{}, "121"},
+		{"unit", src.ParseValue("21.456°C"), &src.ValueFormat{Decimals: &one}, "21.5°C"},
+		{"text", src.TextValue("nixos"), &src.ValueFormat{Decimals: &one}, "nixos"},
+	}
+
+	for _, test := range tests {
+		if result := test.value.Format(test.format); result != test.expected {
+			t.Errorf("For %s, expected %q, but got %q", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestValueUnmarshalOldDataFile(t *testing.T) {
+	var items map[string]src.Value
+	data := `{"user": "grosheth", "gpu": "Not Found", "ram %": {"display": "42.00%", "raw": 42, "unit": "%", "numeric": true}}`
+	if err := json.Unmarshal([]byte(data), &items); err != nil {
+		t.Fatalf("Expected old and new values to decode, got %v", err)
+	}
+	if items["user"].Display != "grosheth" || items["user"].Numeric {
+		t.Errorf("Expected a plain text value, got %+v", items["user"])
+	}
+	if items["gpu"].Err == "" {
+		t.Errorf("Expected Not Found to be marked as an error, got %+v", items["gpu"])
+	}
+	if items["ram %"].Raw != 42 || items["ram %"].Unit != "%" {
+		t.Errorf("Expected a typed percentage, got %+v", items["ram %"])
+	}
+}
+
+func TestBuildBoxMenuWithFormat(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{{Text: "CPU", Keyword: "cpu %", Format: &src.ValueFormat{Percent: "space"}}}
+	items := map[string]src.Value{"cpu %": src.PercentValue(7.5)}
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	if !strings.Contains(menu, "│ CPU    │ 7.50 %") {
+		t.Errorf("Expected the formatted percentage, got:\n%s", menu)
+	}
+}
@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"fmt"
+	"gysmo/gysmo/src"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const openMeteoBody = `{
+  "current": {"temperature_2m": 12.4, "weather_code": 61},
+  "daily": {
+    "time": ["2026-10-19", "2026-10-20"],
+    "weather_code": [61, 0],
+    "temperature_2m_max": [14.2, 16.8],
+    "temperature_2m_min": [6.1, 5.5]
+  }
+}`
+
+const wttrBody = `{
+  "current_condition": [{"temp_C": "3", "temp_F": "37", "weatherCode": "326", "weatherDesc": [{"value": "Light snow"}]}],
+  "weather": [
+    {"date": "2026-10-19", "maxtempC": "4", "mintempC": "-2", "maxtempF": "39", "mintempF": "28", "hourly": [{"weatherCode": "113"}, {"weatherCode": "116"}, {"weatherCode": "326"}]}
+  ]
+}`
+
+func setupWeatherTest(t *testing.T) (*httptest.Server, *int) {
+	t.Setenv("HOME", t.TempDir())
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("latitude") != "45.5" {
+			http.Error(w, "bad latitude", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, openMeteoBody)
+	})
+	mux.HandleFunc("/v1/search", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("name") != "Montreal" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `{"results": [{"name": "Montreal", "latitude": 45.5, "longitude": -73.6}]}`)
+	})
+	mux.HandleFunc("/Montreal", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, wttrBody)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	originalGet := src.HttpGet
+	originalGeocodingURL := src.GeocodingURL
+	src.HttpGet = server.Client().Get
+	src.GeocodingURL = server.URL
+	t.Cleanup(func() {
+		src.HttpGet = originalGet
+		src.GeocodingURL = originalGeocodingURL
+	})
+	return server, &requests
+}
+
+func TestWeatherOpenMeteo(t *testing.T) {
+	server, requests := setupWeatherTest(t)
+	config := src.WeatherConfig{Provider: "open-meteo", Latitude: 45.5, Longitude: -73.6, URL: server.URL}
+
+	if result := src.GetWeather(config).Display; result != "Rain" {
+		t.Errorf("Expected weather Rain, but got %s", result)
+	}
+	if result := src.GetTemperature(config); result.Display != "12°C" || result.Raw != 12.4 || result.Unit != "°C" {
+		t.Errorf("Expected temperature 12°C, but got %+v", result)
+	}
+	if result := src.GetForecast(config).Display; !strings.HasPrefix(result, "Mon") || !strings.Contains(result, "14°/6°") {
+		t.Errorf("Expected forecast starting on Mon with 14°/6°, but got %s", result)
+	}
+	if result := src.GetWeatherIcon(config); result != src.WeatherIcon("rain") {
+		t.Errorf("Expected rain icon, but got %q", result)
+	}
+	if *requests != 1 {
+		t.Errorf("Expected keywords to share one cached request, but got %d requests", *requests)
+	}
+}
+
+func TestWeatherOpenMeteoLocation(t *testing.T) {
+	server, requests := setupWeatherTest(t)
+	config := src.WeatherConfig{Provider: "open-meteo", Location: "Montreal", URL: server.URL}
+
+	if result := src.GetWeather(config).Display; result != "Rain" {
+		t.Errorf("Expected the location to be looked up, but got %s", result)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected a geocoding and a forecast request, but got %d requests", *requests)
+	}
+
+	config.Location = "Nowhere"
+	if result := src.GetWeather(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found for an unknown location, but got %s", result)
+	}
+	config.Location = ""
+	if result := src.GetWeather(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found without a location or coordinates, but got %s", result)
+	}
+	if *requests != 3 {
+		t.Errorf("Expected no forecast request without coordinates, but got %d requests", *requests)
+	}
+}
+
+func TestWeatherCacheKey(t *testing.T) {
+	server, requests := setupWeatherTest(t)
+	config := src.WeatherConfig{Provider: "open-meteo", Latitude: 45.5, Longitude: -73.6, URL: server.URL}
+
+	src.GetWeather(config)
+	src.GetWeather(config)
+	config.Units = "imperial"
+	src.GetWeather(config)
+	config.ForecastDays = 5
+	src.GetWeather(config)
+	if *requests != 3 {
+		t.Errorf("Expected a request for each units and forecast_days, but got %d requests", *requests)
+	}
+
+	config = src.WeatherConfig{Provider: "wttr.in", Location: "Montreal", URL: server.URL}
+	if result := src.GetWeather(config).Display; result != "Light snow" {
+		t.Errorf("Expected the report of wttr.in and not the cached open-meteo one, but got %s", result)
+	}
+}
+
+func TestWeatherWttr(t *testing.T) {
+	server, _ := setupWeatherTest(t)
+	config := src.WeatherConfig{Provider: "wttr.in", Location: "Montreal", Units: "imperial", URL: server.URL, CacheTTL: -1}
+
+	if result := src.GetWeather(config).Display; result != "Light snow" {
+		t.Errorf("Expected weather Light snow, but got %s", result)
+	}
+	if result := src.GetTemperature(config).Display; result != "37°F" {
+		t.Errorf("Expected temperature 37°F, but got %s", result)
+	}
+	if result := src.GetForecast(config).Display; result != "Mon "+src.WeatherIcon("partly cloudy")+" 39°/28°" {
+		t.Errorf("Unexpected forecast %s", result)
+	}
+}
+
+func TestWeatherUnavailable(t *testing.T) {
+	server, _ := setupWeatherTest(t)
+	config := src.WeatherConfig{Provider: "open-meteo", Latitude: 1, URL: server.URL, CacheTTL: -1}
+
+	if result := src.GetWeather(config).Display; result != "Not Found" {
+		t.Errorf("Expected Not Found, but got %s", result)
+	}
+	if result := src.GetWeatherIcon(config); result != src.WeatherIcon("unknown") {
+		t.Errorf("Expected unknown icon, but got %q", result)
+	}
+}
+
+func TestBuildBoxMenuWithAutoIcon(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{{Text: "weather", Keyword: "weather", Icon: "auto"}}
+	items := src.TextValues(map[string]string{"weather": "Rain", src.IconKey("weather"): "R"})
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+	if !strings.Contains(menu, "│ R weather │ Rain") {
+		t.Errorf("Expected the keyword icon to replace auto, got:\n%s", menu)
+	}
+}
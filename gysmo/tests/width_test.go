@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"gysmo/gysmo/src"
+	"strings"
+	"testing"
+)
+
+// setAmbiguousWidth applies a policy for the rest of the test, then goes back to the default one.
+func setAmbiguousWidth(t *testing.T, policy string) {
+	t.Helper()
+	src.SetAmbiguousWidth(policy)
+	t.Cleanup(func() { src.SetAmbiguousWidth("") })
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text   string
+		narrow int
+		wide   int
+		name   string
+	}{
+		{"user", 4, 4, "ascii"},
+		{"\x1b[31mred\x1b[0m", 3, 3, "ansi codes"},
+		{"ユーザー", 8, 8, "katakana"},
+		{"漢字", 4, 4, "cjk"},
+		{"Cafe\u0301", 4, 4, "combining accent"},
+		{"🐧", 2, 2, "emoji"},
+		{"👍🏽", 2, 2, "emoji with modifier"},
+		{"\uf2bd", 1, 2, "nerd font icon"},
+		{"±", 1, 2, "ambiguous"},
+	}
+
+	for _, test := range tests {
+		setAmbiguousWidth(t, "narrow")
+		if result := src.DisplayWidth(test.text); result != test.narrow {
+			t.Errorf("For %s, expected a narrow width of %d, but got %d", test.name, test.narrow, result)
+		}
+		setAmbiguousWidth(t, "wide")
+		if result := src.DisplayWidth(test.text); result != test.wide {
+			t.Errorf("For %s, expected a wide width of %d, but got %d", test.name, test.wide, result)
+		}
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	setAmbiguousWidth(t, "narrow")
+
+	if result := src.PadRight("漢字", 6); result != "漢字  " {
+		t.Errorf("Expected two spaces after the wide characters, but got %q", result)
+	}
+	if result := src.PadRight("toolong", 3); result != "toolong" {
+		t.Errorf("Expected text wider than the width to be kept, but got %q", result)
+	}
+}
+
+// assertAligned checks that every line of the box has its borders in the same columns.
+func assertAligned(t *testing.T, menu string) {
+	t.Helper()
+	borderWidth := -1
+	for _, line := range strings.Split(src.StripAnsiCodes(menu), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		// Only keep the box, values are outside of it
+		if index := strings.LastIndex(line, "│ "); strings.HasPrefix(line, "│") && index > 0 {
+			line = line[:index+len("│")]
+		}
+		if borderWidth == -1 {
+			borderWidth = src.DisplayWidth(line)
+		} else if width := src.DisplayWidth(line); width != borderWidth {
+			t.Errorf("Expected every line of the box to be %d cells wide, but %q is %d:\n%s", borderWidth, line, width, menu)
+		}
+	}
+}
+
+func TestBuildBoxMenuWithWideCharacters(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Header.Text = "システム"
+	config.Items = []src.ConfigItem{
+		{Text: "ユーザー", Icon: "🐧", Keyword: "user"},
+		{Text: "Cafe\u0301", Icon: "\uf2bd", Keyword: "shell"},
+		{Text: "RAM", Icon: "", Keyword: "ram %", Display: "bar"},
+	}
+	items := map[string]src.Value{"user": src.TextValue("漢字"), "shell": src.TextValue("zsh"), "ram %": src.PercentValue(50)}
+
+	for _, policy := range []string{"narrow", "wide", "locale"} {
+		setAmbiguousWidth(t, policy)
+		assertAligned(t, src.BuildBoxMenu(items, "", config))
+	}
+}
+
+func TestBuildBoxMenuWidthExactLines(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.General.MenuPadding = 0
+	config.Header.Text = "システム"
+	config.Footer.Text = "±"
+	config.Items = []src.ConfigItem{
+		{Text: "ユーザー", Icon: "🐧", Keyword: "user"},
+		{Text: "Cafe\u0301", Icon: "\uf2bd", Keyword: "shell"},
+	}
+	items := map[string]src.Value{"user": src.TextValue("漢字"), "shell": src.TextValue("zsh")}
+
+	tests := []struct {
+		policy   string
+		expected []string
+	}{
+		{"narrow", []string{
+			"╭─────────────╮",
+			"│ システム    │",
+			"├─────────────┤",
+			"│ 🐧 ユーザー │ 漢字",
+			"│ \uf2bd  Cafe\u0301     │ zsh",
+			"├─────────────┤",
+			"│ ±           │",
+			"╰─────────────╯",
+		}},
+		// Box drawing characters and the icon are ambiguous, so they take two cells
+		{"wide", []string{
+			"╭───────╮",
+			"│ システム     │",
+			"├───────┤",
+			"│ 🐧 ユーザー  │ 漢字",
+			"│ \uf2bd Cafe\u0301      │ zsh",
+			"├───────┤",
+			"│ ±           │",
+			"╰───────╯",
+		}},
+	}
+
+	for _, test := range tests {
+		setAmbiguousWidth(t, test.policy)
+		menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "", config))
+		lines := strings.Split(strings.TrimRight(menu, "\n"), "\n")
+		lines = lines[len(lines)-len(test.expected):]
+		if strings.Join(lines, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("With the %s policy, expected:\n%s\nGot:\n%s", test.policy, strings.Join(test.expected, "\n"), strings.Join(lines, "\n"))
+		}
+	}
+}
+
+func TestBuildListMenuWithWideCharacters(t *testing.T) {
+	config := GetConfigWithAscii("top")
+	config.Ascii.Enabled = false
+	config.Items = []src.ConfigItem{
+		{Text: "ユーザー", Icon: "🐧", Keyword: "user"},
+		{Text: "Cafe\u0301", Icon: "\uf2bd", Keyword: "shell"},
+	}
+	items := map[string]src.Value{"user": src.TextValue("漢字"), "shell": src.TextValue("zsh")}
+
+	menu := src.StripAnsiCodes(src.BuildListMenu(items, "", config))
+	columns := []int{}
+	for _, line := range strings.Split(menu, "\n") {
+		for _, value := range []string{"漢字", "zsh"} {
+			if index := strings.Index(line, value); index >= 0 {
+				columns = append(columns, src.DisplayWidth(line[:index]))
+			}
+		}
+	}
+	if len(columns) != 2 || columns[0] != columns[1] {
+		t.Errorf("Expected the values to start in the same column, got %v:\n%s", columns, menu)
+	}
+}
+
+func TestBuildBoxMenuWithWideCharactersAndAsciiRight(t *testing.T) {
+	config := GetConfigWithAscii("right")
+	config.Items = []src.ConfigItem{
+		{Text: "ユーザー", Icon: "🐧", Keyword: "user"},
+		{Text: "shell", Icon: "\uf2bd", Keyword: "shell"},
+	}
+	items := map[string]src.Value{"user": src.TextValue("漢字漢字"), "shell": src.TextValue("zsh")}
+
+	menu := src.StripAnsiCodes(src.BuildBoxMenu(items, "@\n@\n@\n@\n@\n@\n@", config))
+	columns := map[int]bool{}
+	for _, line := range strings.Split(menu, "\n") {
+		if index := strings.Index(line, "@"); index >= 0 {
+			columns[src.DisplayWidth(line[:index])] = true
+		}
+	}
+	if len(columns) != 1 {
+		t.Errorf("Expected the ASCII art to start in a single column, got %v:\n%s", columns, menu)
+	}
+}